@@ -0,0 +1,76 @@
+// Package access filters the fields of payloads that are used in both directions by their access mode,
+// declared in the payload tag: a readOnly field, such as an id, is ignored when a client sends it,
+// and a writeOnly field, such as a password, is never sent to a client.
+package access
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/walk"
+	"github.com/binadel/payloads/jsonpointer"
+	"github.com/binadel/payloads/validate"
+)
+
+// StripReadOnly makes the fields tagged with `payload:"readOnly"` undefined in the decoded payload v,
+// which must be a pointer, and returns the JSON Pointers of those that were sent. A nullable field becomes null,
+// and a plain field its zero value. The nested structs, such as the values of optional.Object and the items
+// of optional.Array, are filtered too.
+func StripReadOnly(v any) ([]string, error) {
+	return strip(v, "readOnly")
+}
+
+// RejectReadOnly is like StripReadOnly, but it reports the read-only fields that were sent as validate.Violations,
+// which convert to a validation problem. The fields are stripped whether or not they are reported.
+func RejectReadOnly(v any) error {
+	sent, err := StripReadOnly(v)
+	if err != nil {
+		return err
+	}
+	var violations validate.Violations
+	for _, pointer := range sent {
+		tokens, _ := jsonpointer.Parse(pointer)
+		violations = append(violations, validate.Violation{
+			Pointer: pointer,
+			Rule:    "readOnly",
+			Message: fmt.Sprintf("%s is read-only", tokens[len(tokens)-1]),
+		})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations
+}
+
+// StripWriteOnly makes the fields tagged with `payload:"writeOnly"` undefined in the payload v, which must be
+// a pointer, before it is encoded, so that they are omitted as undefined fields with the omitempty option.
+// It filters nested structs like StripReadOnly.
+func StripWriteOnly(v any) error {
+	_, err := strip(v, "writeOnly")
+	return err
+}
+
+// strip unsets the fields with the given option, and returns the pointers of those that were defined.
+func strip(v any, option string) ([]string, error) {
+	if rv := reflect.ValueOf(v); rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return nil, fmt.Errorf("access: %T is not a pointer", v)
+	}
+	var defined, all []string
+	walk.Fields(reflect.ValueOf(v), func(_ reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		if _, ok := f.Option(option); !ok {
+			return true, nil
+		}
+		all = append(all, path.Pointer)
+		if fields.Defined(value) {
+			defined = append(defined, path.Pointer)
+		}
+		return false, nil
+	})
+	for _, pointer := range all {
+		if err := jsonpointer.Unset(v, pointer); err != nil {
+			return nil, err
+		}
+	}
+	return defined, nil
+}
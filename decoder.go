@@ -0,0 +1,65 @@
+package payloads
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/scope"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// Decoder decodes payloads from untrusted input, checking them against its options before decoding,
+// so that a hostile payload is rejected before anything is allocated for it. Decoding with easyjson
+// or encoding/json directly only enforces DefaultLimits, see Limits.
+type Decoder struct {
+	Limits Limits
+
+	// AcceptNonFinite makes the float containers accept the strings "NaN", "Infinity" and "-Infinity"
+	// in place of a number, by default only numbers are accepted.
+	AcceptNonFinite bool
+
+	// DisallowUnknownFields rejects object members that do not match a field of the destination struct.
+	DisallowUnknownFields bool
+
+	// DisallowDuplicateFields rejects objects that repeat a member name,
+	// which would otherwise be decoded with the last occurrence winning.
+	DisallowDuplicateFields bool
+}
+
+// Unmarshal checks data against the options of the decoder and decodes it into v.
+// The optional fields of the structs held by v whose member is null are defined,
+// which the code generated by easyjson does not do on its own.
+// All the unknown and duplicate fields are reported at once, joined in a single error.
+func (d Decoder) Unmarshal(data []byte, v easyjson.Unmarshaler) error {
+	if err := d.Limits.Check(data); err != nil {
+		return err
+	}
+	if d.DisallowUnknownFields || d.DisallowDuplicateFields {
+		err := checkStrict(data, reflect.TypeOf(v), d.DisallowUnknownFields, d.DisallowDuplicateFields)
+		if err != nil {
+			return err
+		}
+	}
+	l := jlexer.Lexer{Data: data}
+	defer scope.Bind(&l, &d)()
+	v.UnmarshalEasyJSON(&l)
+	if err := l.Error(); err != nil {
+		return err
+	}
+	defineNulls(data, reflect.ValueOf(v))
+	return nil
+}
+
+// Decode reads the payload from r and decodes it like Unmarshal.
+// If MaxBytes is set, it stops reading as soon as the limit is exceeded.
+func (d Decoder) Decode(r io.Reader, v easyjson.Unmarshaler) error {
+	if d.Limits.MaxBytes > 0 {
+		r = io.LimitReader(r, int64(d.Limits.MaxBytes)+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return d.Unmarshal(data, v)
+}
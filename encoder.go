@@ -0,0 +1,41 @@
+package payloads
+
+import (
+	"io"
+
+	"github.com/binadel/payloads/internal/scope"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Encoder encodes payloads with its options, which apply to every container of the payload.
+// Marshaling with easyjson or encoding/json directly uses the zero options.
+type Encoder struct {
+	// NonFinite determines how the float containers write NaN and infinite values,
+	// by default marshaling them fails.
+	NonFinite NonFiniteMode
+
+	// ExposeSecrets makes the Secret containers write their value, by default they write "[REDACTED]"
+	// unless exposed one by one with SetExposed.
+	ExposeSecrets bool
+}
+
+// Marshal encodes v with the options of the encoder.
+func (e Encoder) Marshal(v easyjson.Marshaler) ([]byte, error) {
+	w := jwriter.Writer{}
+	defer scope.Bind(&w, &e)()
+	v.MarshalEasyJSON(&w)
+	return w.BuildBytes()
+}
+
+// Encode writes the encoding of v to out, like Marshal.
+func (e Encoder) Encode(out io.Writer, v easyjson.Marshaler) error {
+	w := jwriter.Writer{}
+	defer scope.Bind(&w, &e)()
+	v.MarshalEasyJSON(&w)
+	if w.Error != nil {
+		return w.Error
+	}
+	_, err := w.DumpTo(out)
+	return err
+}
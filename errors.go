@@ -0,0 +1,50 @@
+// Package payloads contains the definitions shared by the payload containers,
+// such as the errors reported while decoding them.
+package payloads
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrOverflow is reported when a number does not fit in the range of the expected Go type.
+	ErrOverflow = errors.New("number out of range")
+
+	// ErrFraction is reported when a number with a fractional part is decoded into an integer type.
+	ErrFraction = errors.New("number has a fractional part")
+
+	// ErrKind is reported when the JSON value is of a different kind than the expected Go type,
+	// such as a string decoded into an integer type.
+	ErrKind = errors.New("unexpected kind of value")
+
+	// ErrFormat is reported when a string does not have the format expected by the Go type,
+	// such as base64 for a byte slice.
+	ErrFormat = errors.New("invalid format")
+
+	// ErrDiscriminator is reported when a polymorphic object has a missing or unknown discriminator member.
+	ErrDiscriminator = errors.New("missing or unknown discriminator")
+)
+
+// TypeError describes a JSON value that cannot be decoded into the expected Go type.
+// Err is one of ErrOverflow, ErrFraction, ErrKind, ErrFormat or ErrDiscriminator, so it can be checked with errors.Is.
+type TypeError struct {
+	// GoType is the name of the expected Go type, such as "uint8".
+	GoType string
+
+	// Literal is the offending JSON value, truncated if it is too long.
+	Literal string
+
+	// Offset is the position of the offending value in the input, see Locate.
+	Offset int
+
+	Err error
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("cannot decode %s into %s: %v", e.Literal, e.GoType, e.Err)
+}
+
+func (e *TypeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,151 @@
+// Package fieldmask lists the fields that are defined in a decoded payload, such as the fields sent in the body
+// of a PATCH request, and clears the fields that are not in a given list.
+package fieldmask
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/walk"
+	"github.com/binadel/payloads/jsonpointer"
+)
+
+// Pointers returns the JSON Pointers of the defined fields of the payload v, a struct or a pointer to one,
+// in declaration order. An optional field is defined if it was decoded, even from null, or marked as defined,
+// whether it holds a value or not, and a nullable field if it holds a value. The fields of a nested struct,
+// such as the value of an optional.Object, are listed instead of the struct itself, unless it has no defined
+// field. Plain fields are not listed, but the plain structs are walked.
+func Pointers(v any) []string {
+	var pointers []string
+	for _, p := range defined(reflect.ValueOf(v)) {
+		pointers = append(pointers, p.Pointer)
+	}
+	return pointers
+}
+
+// Paths is like Pointers, but it returns paths made of the member names separated by dots, such as address.city.
+func Paths(v any) []string {
+	var paths []string
+	for _, p := range defined(reflect.ValueOf(v)) {
+		paths = append(paths, strings.Join(p.Names, "."))
+	}
+	return paths
+}
+
+// Clear clears the fields of the payload v, which must be a pointer to a struct, that are not in the mask:
+// an optional field becomes undefined and a nullable field null. The mask lists JSON Pointers, or paths
+// separated by dots, as returned by Pointers and Paths. A field in the mask is kept with all its nested fields,
+// and a nested struct containing a field in the mask is kept, with its other fields cleared.
+// The items of arrays of structs, such as optional.Array, are cleared alike, the mask naming their fields
+// without an index: items.price keeps the price of every item.
+func Clear(v any, mask []string) error {
+	keep := make([][]string, 0, len(mask))
+	for _, m := range mask {
+		tokens, err := parse(m)
+		if err != nil {
+			return err
+		}
+		keep = append(keep, tokens)
+	}
+	var clear []string
+	walk.Fields(reflect.ValueOf(v), func(_ reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		switch match(path.Names, keep) {
+		case kept:
+			return false, nil
+		case partial:
+			return nested(value), nil
+		}
+		if kind, _ := fields.Container(f.Type); kind != fields.Plain {
+			clear = append(clear, path.Pointer)
+			return false, nil
+		}
+		return nested(value), nil
+	})
+	for _, pointer := range clear {
+		if err := jsonpointer.Unset(v, pointer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parse returns the member names of a JSON Pointer or of a path separated by dots.
+func parse(path string) ([]string, error) {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return jsonpointer.Parse(path)
+	}
+	return strings.Split(path, "."), nil
+}
+
+// defined returns the paths of the defined fields of the struct held by v, leaving out those of the nested structs
+// that have defined fields, which are listed instead.
+func defined(v reflect.Value) []walk.Path {
+	var paths []walk.Path
+	walk.Fields(v, func(_ reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		_, isStruct := diff.Struct(value)
+		if kind, _ := fields.Container(f.Type); kind == fields.Plain || !fields.Defined(value) {
+			return isStruct && kind == fields.Plain, nil
+		}
+		paths = append(paths, path)
+		return isStruct, nil
+	})
+	// The fields of a struct are walked right after it, so it is followed by its first defined field if it has one.
+	listed := paths[:0]
+	for i, p := range paths {
+		if i+1 < len(paths) && len(paths[i+1].Names) > len(p.Names) && isPrefix(p.Names, paths[i+1].Names) {
+			continue
+		}
+		listed = append(listed, p)
+	}
+	return listed
+}
+
+// nested determines whether the fields of a field are walked to be cleared:
+// it must hold a struct or an array of structs, without a custom encoding.
+func nested(v reflect.Value) bool {
+	if _, ok := diff.Struct(v); ok {
+		return true
+	}
+	held := walk.Held(v)
+	if !held.IsValid() || (held.Kind() != reflect.Slice && held.Kind() != reflect.Array) {
+		return false
+	}
+	elem := elemStruct(held.Type())
+	return elem != nil && !diff.Custom(elem)
+}
+
+type matching int
+
+const (
+	none matching = iota
+	partial
+	kept
+)
+
+// match tells whether path is in the mask or in a kept field, or contains a field of the mask.
+func match(path []string, keep [][]string) matching {
+	result := none
+	for _, k := range keep {
+		switch {
+		case isPrefix(k, path):
+			return kept
+		case isPrefix(path, k):
+			result = partial
+		}
+	}
+	return result
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
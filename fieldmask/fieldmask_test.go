@@ -0,0 +1,54 @@
+package fieldmask_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/binadel/payloads/fieldmask"
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/optional"
+)
+
+type address struct {
+	City optional.String `json:"city"`
+	Zip  optional.String `json:"zip"`
+}
+
+type user struct {
+	Name    optional.String              `json:"name"`
+	Age     optional.Int                 `json:"age"`
+	Bio     nullable.String              `json:"bio"`
+	Address optional.AnyObject[*address] `json:"address"`
+}
+
+func TestPointers(t *testing.T) {
+	tests := []struct {
+		name string
+		user func() user
+		want []string
+	}{
+		{"Decoded", func() user {
+			var u user
+			if err := json.Unmarshal([]byte(`{"name":null,"bio":"x","address":{"zip":"75001"}}`), &u); err != nil {
+				t.Fatal(err)
+			}
+			return u
+		}, []string{"/name", "/bio", "/address/zip"}},
+		{"SetWithoutSetDefined", func() user {
+			var u user
+			u.Name.Set("Ada")
+			u.Age = optional.Of[optional.Int](36)
+			return u
+		}, []string{"/age"}},
+		{"Empty", func() user { return user{} }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.user()
+			if got := fieldmask.Pointers(&u); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pointers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package fieldmask
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/problem"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// UnknownFieldError reports a path of a field selection that does not name a field of the payload type.
+type UnknownFieldError struct {
+	// Path is the path as it was selected, such as owner.mail.
+	Path string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("fieldmask: unknown field %s", e.Path)
+}
+
+// Problem returns the details of a bad request problem, since a field selection comes from the request URL.
+func (e *UnknownFieldError) Problem() problem.Details {
+	return problem.Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusBadRequest),
+		Status: http.StatusBadRequest,
+		Detail: fmt.Sprintf("The field %s does not exist.", e.Path),
+	}
+}
+
+// Parse splits a sparse fieldset, such as the value of the query parameter ?fields=id,name,owner.email,
+// into paths, ignoring spaces and empty paths.
+func Parse(fieldset string) []string {
+	var paths []string
+	for _, path := range strings.Split(fieldset, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Check verifies that the paths, which are JSON Pointers or paths separated by dots, name fields of the type of v,
+// through nested structs and the items of arrays of structs. It returns an *UnknownFieldError for the first
+// path that does not.
+func Check(v any, paths []string) error {
+	for _, path := range paths {
+		tokens, err := parse(path)
+		if err != nil {
+			return &UnknownFieldError{Path: path}
+		}
+		t := reflect.TypeOf(v)
+		for _, token := range tokens {
+			s := elemStruct(t)
+			if s == nil {
+				return &UnknownFieldError{Path: path}
+			}
+			f, ok := fields.ByName(s, token)
+			if !ok {
+				return &UnknownFieldError{Path: path}
+			}
+			t = f.Type
+		}
+	}
+	return nil
+}
+
+// Select returns the JSON encoding of the payload v, a struct or a pointer to one, with only the selected fields.
+// It checks the paths with Check, encodes v with easyjson, or encoding/json if v does not implement its marshaler
+// interface, then leaves out the other members with Filter. The fields that are not selected are left out whatever
+// their kind, so that a nullable or plain field is not mistaken for a null or zero one. To encode v with other
+// options, such as those of a payloads.Encoder, call Check and Filter directly.
+func Select(v any, paths []string) ([]byte, error) {
+	if err := Check(v, paths); err != nil {
+		return nil, err
+	}
+	data, err := diff.Encode(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return Filter(data, paths)
+}
+
+// Filter returns the JSON document data without the members of its objects that are not selected by the paths,
+// which are JSON Pointers or paths separated by dots, matched against the member names like a mask of Clear:
+// a selected member is kept with all its nested members, and an object containing a selected member is kept,
+// with its other members left out. The items of arrays are filtered alike, the paths naming their members
+// without an index. Filter does not check the paths, so the members that no path selects are all left out.
+func Filter(data []byte, paths []string) ([]byte, error) {
+	keep := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		tokens, err := parse(path)
+		if err != nil {
+			return nil, err
+		}
+		keep = append(keep, tokens)
+	}
+	l := jlexer.Lexer{Data: data}
+	w := jwriter.Writer{}
+	filter(&l, &w, nil, keep)
+	l.Consumed()
+	if err := l.Error(); err != nil {
+		return nil, err
+	}
+	return w.BuildBytes()
+}
+
+// filter copies the next value of l to w, leaving out the members that are not kept. The names are the member
+// names of the path of the value, which is either partially kept or the root of the document.
+func filter(l *jlexer.Lexer, w *jwriter.Writer, names []string, keep [][]string) {
+	switch {
+	case l.IsDelim('{'):
+		l.Delim('{')
+		w.RawByte('{')
+		first := true
+		for !l.IsDelim('}') {
+			name := l.UnsafeFieldName(false)
+			l.WantColon()
+			path := append(names[:len(names):len(names)], name)
+			m := match(path, keep)
+			if m == none {
+				l.SkipRecursive()
+				l.WantComma()
+				continue
+			}
+			if !first {
+				w.RawByte(',')
+			}
+			first = false
+			w.String(name)
+			w.RawByte(':')
+			if m == kept {
+				w.Raw(l.Raw(), l.Error())
+			} else {
+				filter(l, w, path, keep)
+			}
+			l.WantComma()
+		}
+		l.Delim('}')
+		w.RawByte('}')
+	case l.IsDelim('['):
+		l.Delim('[')
+		w.RawByte('[')
+		for i := 0; !l.IsDelim(']'); i++ {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			filter(l, w, names, keep)
+			l.WantComma()
+		}
+		l.Delim(']')
+		w.RawByte(']')
+	default:
+		w.Raw(l.Raw(), l.Error())
+	}
+}
+
+// elemStruct returns the struct type held by t, through pointers, containers, slices and arrays, or nil if there is none.
+func elemStruct(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			if t.Kind() != reflect.Pointer && t.Elem().Kind() == reflect.Uint8 {
+				return nil
+			}
+			t = t.Elem()
+		case reflect.Struct:
+			kind, value := fields.Container(t)
+			if kind == fields.Plain {
+				return t
+			}
+			t = value
+		default:
+			return nil
+		}
+	}
+	return nil
+}
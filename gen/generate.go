@@ -8,6 +8,15 @@ import (
 
 type PrimitiveTemplateParams struct {
 	TypeName, GoType, WriterMethod, LexerMethod string
+
+	// StringLexerMethod is set for types that are encoded as JSON strings,
+	// it decodes the string form, while LexerMethod decodes the number form.
+	StringLexerMethod string
+}
+
+// IsFloat determines whether the type needs the encoding of NaN and infinite values.
+func (p PrimitiveTemplateParams) IsFloat() bool {
+	return strings.HasPrefix(p.GoType, "float")
 }
 
 func main() {
@@ -17,29 +26,48 @@ func main() {
 
 func getPrimitiveTemplateArgs() []PrimitiveTemplateParams {
 	return []PrimitiveTemplateParams{
-		{"Bool", "bool", "Bool", "Bool"},
+		{"Bool", "bool", "Bool", "Bool", ""},
 
-		{"Int", "int", "Int", "Int"},
-		{"Int8", "int8", "Int8", "Int8"},
-		{"Int16", "int16", "Int16", "Int16"},
-		{"Int32", "int32", "Int32", "Int32"},
-		{"Int64", "int64", "Int64", "Int64"},
+		{"Int", "int", "Int", "Int", ""},
+		{"Int8", "int8", "Int8", "Int8", ""},
+		{"Int16", "int16", "Int16", "Int16", ""},
+		{"Int32", "int32", "Int32", "Int32", ""},
+		{"Int64", "int64", "Int64", "Int64", ""},
 
-		{"UInt", "uint", "Uint", "Uint"},
-		{"UInt8", "uint8", "Uint8", "Uint8"},
-		{"UInt16", "uint16", "Uint16", "Uint16"},
-		{"UInt32", "uint32", "Uint32", "Uint32"},
-		{"UInt64", "uint64", "Uint64", "Uint64"},
+		{"UInt", "uint", "Uint", "Uint", ""},
+		{"UInt8", "uint8", "Uint8", "Uint8", ""},
+		{"UInt16", "uint16", "Uint16", "Uint16", ""},
+		{"UInt32", "uint32", "Uint32", "Uint32", ""},
+		{"UInt64", "uint64", "Uint64", "Uint64", ""},
 
-		{"Float32", "float32", "Float32", "Float32"},
-		{"Float64", "float64", "Float64", "Float64"},
+		{"Float32", "float32", "Float32", "Float32", ""},
+		{"Float64", "float64", "Float64", "Float64", ""},
 
-		{"String", "string", "String", "String"},
+		{"String", "string", "String", "String", ""},
+	}
+}
+
+// getStringIntegerTemplateArgs returns the string encoded variants of the integer types,
+// such as Int64String, which are written as JSON strings to avoid precision loss in JavaScript clients.
+func getStringIntegerTemplateArgs() []PrimitiveTemplateParams {
+	var types []PrimitiveTemplateParams
+	for _, t := range getPrimitiveTemplateArgs() {
+		if !strings.HasPrefix(t.TypeName, "Int") && !strings.HasPrefix(t.TypeName, "UInt") {
+			continue
+		}
+		types = append(types, PrimitiveTemplateParams{
+			TypeName:          t.TypeName + "String",
+			GoType:            t.GoType,
+			WriterMethod:      t.WriterMethod + "Str",
+			LexerMethod:       t.LexerMethod,
+			StringLexerMethod: t.LexerMethod + "Str",
+		})
 	}
+	return types
 }
 
 func generateNullableTypes() {
-	types := getPrimitiveTemplateArgs()
+	types := append(getPrimitiveTemplateArgs(), getStringIntegerTemplateArgs()...)
 
 	tmpl := template.Must(template.ParseFiles("templates/nullable.tmpl"))
 
@@ -54,7 +82,7 @@ func generateNullableTypes() {
 }
 
 func generateOptionalTypes() {
-	types := getPrimitiveTemplateArgs()
+	types := append(getPrimitiveTemplateArgs(), getStringIntegerTemplateArgs()...)
 
 	tmpl := template.Must(template.ParseFiles("templates/optional.tmpl"))
 	for _, t := range types {
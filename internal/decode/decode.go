@@ -0,0 +1,398 @@
+// Package decode implements the strict decoding of primitive values used by the generated containers.
+// Unlike the jlexer methods, it reports overflow, fractional numbers and unexpected kinds of values
+// as payloads.TypeError.
+package decode
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/binadel/payloads/internal/number"
+	"github.com/binadel/payloads/internal/scope"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// maxLiteralLen is the length after which the literal reported in errors is truncated.
+const maxLiteralLen = 64
+
+// literal reads the next value as raw bytes, returning it along with its offset.
+func literal(l *jlexer.Lexer) ([]byte, int) {
+	raw := l.Raw()
+	return raw, l.GetPos() - len(raw)
+}
+
+// fail reports a TypeError for the given value.
+func fail(l *jlexer.Lexer, goType string, raw []byte, offset int, err error) {
+	lit := string(raw)
+	if len(lit) > maxLiteralLen {
+		lit = lit[:maxLiteralLen-3] + "..."
+	}
+	l.AddError(&payloads.TypeError{GoType: goType, Literal: lit, Offset: offset, Err: err})
+}
+
+// next reads the next value if it is of the given kind, otherwise it reports a TypeError.
+func next(l *jlexer.Lexer, kind jlexer.TokenKind, goType string) ([]byte, int, bool) {
+	k := l.CurrentToken()
+	raw, offset := literal(l)
+	if !l.Ok() {
+		return nil, 0, false
+	}
+	if k != kind {
+		fail(l, goType, raw, offset, payloads.ErrKind)
+		return nil, 0, false
+	}
+	return raw, offset, true
+}
+
+// decoder returns the payloads.Decoder that is decoding from l, if any.
+func decoder(l *jlexer.Lexer) *payloads.Decoder {
+	d, _ := scope.Lookup(l).(*payloads.Decoder)
+	return d
+}
+
+// limits returns the limits that apply while decoding from l: those of the payloads.Decoder decoding from it,
+// or payloads.DefaultLimits.
+func limits(l *jlexer.Lexer) payloads.Limits {
+	if d := decoder(l); d != nil {
+		return d.Limits
+	}
+	return payloads.DefaultLimits
+}
+
+// Limits checks the whole input of l against payloads.DefaultLimits when a container starts decoding at its start,
+// since the container is then the whole payload. The input of a payloads.Decoder was already checked against
+// its own limits.
+func Limits(l *jlexer.Lexer) {
+	if !l.IsStart() || decoder(l) != nil {
+		return
+	}
+	if err := payloads.DefaultLimits.Check(l.Data); err != nil {
+		l.AddError(err)
+	}
+}
+
+// LimitsJSON checks data against payloads.DefaultLimits, for the containers decoded with encoding/json,
+// which receive the whole value to decode but not the payloads.Decoder decoding it.
+func LimitsJSON(data []byte) error {
+	return payloads.DefaultLimits.Check(data)
+}
+
+// Item reports a payloads.LimitError if an array that already has n items cannot have another one under
+// the MaxItems limit. It is called before decoding each item, which is skipped if the limit is exceeded.
+func Item(l *jlexer.Lexer, n int) {
+	if max := limits(l).MaxItems; max > 0 && n >= max {
+		raw, offset := literal(l)
+		if raw != nil {
+			l.AddError(&payloads.LimitError{Limit: "MaxItems", Max: max, Offset: offset})
+		}
+	}
+}
+
+// str decodes the next value, which must be a string, reporting a payloads.LimitError if it is longer than
+// the MaxStringBytes limit.
+func str(l *jlexer.Lexer) string {
+	max := limits(l).MaxStringBytes
+	if max <= 0 {
+		return l.String()
+	}
+	raw, offset := literal(l)
+	s := unquote(raw)
+	if len(s) > max {
+		l.AddError(&payloads.LimitError{Limit: "MaxStringBytes", Max: max, Offset: offset})
+		return ""
+	}
+	return s
+}
+
+// unquote decodes a raw JSON string.
+func unquote(raw []byte) string {
+	l := jlexer.Lexer{Data: raw}
+	return l.String()
+}
+
+// parseInt parses an integer literal, accepting integral values written with a fraction or an exponent.
+func parseInt(s string, bits int) (int64, error) {
+	// strconv accepts a leading + and leading zeros, which the JSON grammar does not, even in a string.
+	if !number.Valid(s) {
+		return 0, payloads.ErrKind
+	}
+	n, err := strconv.ParseInt(s, 10, bits)
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, payloads.ErrOverflow
+	}
+	i, err := number.Int(s)
+	if err != nil {
+		if _, err := number.Rat(s); err == nil {
+			return 0, payloads.ErrFraction
+		}
+		// A valid literal is only rejected by number.Rat when its exponent is out of range.
+		return 0, payloads.ErrOverflow
+	}
+	if !i.IsInt64() {
+		return 0, payloads.ErrOverflow
+	}
+	if n := i.Int64(); bits < 64 && (n < -1<<(bits-1) || n > 1<<(bits-1)-1) {
+		return 0, payloads.ErrOverflow
+	}
+	return i.Int64(), nil
+}
+
+// parseUint parses an unsigned integer literal, accepting integral values written with a fraction or an exponent.
+func parseUint(s string, bits int) (uint64, error) {
+	// strconv accepts a leading + and leading zeros, which the JSON grammar does not, even in a string.
+	if !number.Valid(s) {
+		return 0, payloads.ErrKind
+	}
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, payloads.ErrOverflow
+	}
+	i, err := number.Int(s)
+	if err != nil {
+		if _, err := number.Rat(s); err == nil {
+			return 0, payloads.ErrFraction
+		}
+		// A valid literal is only rejected by number.Rat when its exponent is out of range.
+		return 0, payloads.ErrOverflow
+	}
+	if !i.IsUint64() || (bits < 64 && i.Uint64() >= 1<<bits) {
+		return 0, payloads.ErrOverflow
+	}
+	return i.Uint64(), nil
+}
+
+// signed decodes a number into a signed integer of the given size.
+func signed(l *jlexer.Lexer, bits int, goType string) int64 {
+	raw, offset, ok := next(l, jlexer.TokenNumber, goType)
+	if !ok {
+		return 0
+	}
+	n, err := parseInt(string(raw), bits)
+	if err != nil {
+		fail(l, goType, raw, offset, err)
+	}
+	return n
+}
+
+// signedStr decodes a string holding a number into a signed integer of the given size.
+func signedStr(l *jlexer.Lexer, bits int, goType string) int64 {
+	raw, offset, ok := next(l, jlexer.TokenString, goType)
+	if !ok {
+		return 0
+	}
+	n, err := parseInt(unquote(raw), bits)
+	if err != nil {
+		fail(l, goType, raw, offset, err)
+	}
+	return n
+}
+
+// unsigned decodes a number into an unsigned integer of the given size.
+func unsigned(l *jlexer.Lexer, bits int, goType string) uint64 {
+	raw, offset, ok := next(l, jlexer.TokenNumber, goType)
+	if !ok {
+		return 0
+	}
+	n, err := parseUint(string(raw), bits)
+	if err != nil {
+		fail(l, goType, raw, offset, err)
+	}
+	return n
+}
+
+// unsignedStr decodes a string holding a number into an unsigned integer of the given size.
+func unsignedStr(l *jlexer.Lexer, bits int, goType string) uint64 {
+	raw, offset, ok := next(l, jlexer.TokenString, goType)
+	if !ok {
+		return 0
+	}
+	n, err := parseUint(unquote(raw), bits)
+	if err != nil {
+		fail(l, goType, raw, offset, err)
+	}
+	return n
+}
+
+// float decodes a number into a floating point number of the given size.
+// The strings "NaN", "Infinity" and "-Infinity" are accepted if the payloads.Decoder allows them.
+func float(l *jlexer.Lexer, bits int, goType string) float64 {
+	if d := decoder(l); d != nil && d.AcceptNonFinite && l.CurrentToken() == jlexer.TokenString {
+		raw, offset := literal(l)
+		switch unquote(raw) {
+		case "NaN":
+			return math.NaN()
+		case "Infinity":
+			return math.Inf(1)
+		case "-Infinity":
+			return math.Inf(-1)
+		}
+		if l.Ok() {
+			fail(l, goType, raw, offset, payloads.ErrKind)
+		}
+		return 0
+	}
+	raw, offset, ok := next(l, jlexer.TokenNumber, goType)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseFloat(string(raw), bits)
+	if err != nil || math.IsInf(n, 0) {
+		fail(l, goType, raw, offset, payloads.ErrOverflow)
+		return 0
+	}
+	return n
+}
+
+// Number decodes a number literal, keeping it as it was written. A string is reported as a TypeError
+// rather than accepted as jlexer does, so that the value is encoded back as the same kind of JSON value.
+func Number(l *jlexer.Lexer) json.Number {
+	raw, _, ok := next(l, jlexer.TokenNumber, "json.Number")
+	if !ok {
+		return ""
+	}
+	if !number.Valid(string(raw)) {
+		l.AddError(&jlexer.LexerError{
+			Reason: "invalid number literal",
+			Offset: l.GetPos(),
+			Data:   string(raw),
+		})
+	}
+	return json.Number(raw)
+}
+
+// Bool decodes a boolean value.
+func Bool(l *jlexer.Lexer) bool {
+	if l.CurrentToken() == jlexer.TokenBool {
+		return l.Bool()
+	}
+	next(l, jlexer.TokenBool, "bool")
+	return false
+}
+
+// String decodes a string value.
+func String(l *jlexer.Lexer) string {
+	if l.CurrentToken() == jlexer.TokenString {
+		return str(l)
+	}
+	next(l, jlexer.TokenString, "string")
+	return ""
+}
+
+// Secret decodes a string value like String, but without revealing the value in errors.
+func Secret(l *jlexer.Lexer) string {
+	if l.CurrentToken() == jlexer.TokenString {
+		return str(l)
+	}
+	_, offset := literal(l)
+	if l.Ok() {
+		l.AddError(&payloads.TypeError{GoType: "string", Literal: format.Redacted, Offset: offset, Err: payloads.ErrKind})
+	}
+	return ""
+}
+
+// Bytes decodes a base64 string, in the standard or URL-safe alphabet, with or without padding.
+// It reports a payloads.LimitError if the decoded data is longer than the MaxBytesLength limit.
+func Bytes(l *jlexer.Lexer) []byte {
+	raw, offset, ok := next(l, jlexer.TokenString, "[]byte")
+	if !ok {
+		return nil
+	}
+	s := unquote(raw)
+
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	if strings.HasSuffix(s, "=") {
+		enc = enc.WithPadding(base64.StdPadding)
+	}
+
+	if limit := limits(l).MaxBytesLength; limit > 0 && enc.DecodedLen(len(s)) > limit {
+		l.AddError(&payloads.LimitError{Limit: "MaxBytesLength", Max: limit, Offset: offset})
+		return nil
+	}
+	data, err := enc.DecodeString(s)
+	if err != nil {
+		fail(l, "[]byte", raw, offset, payloads.ErrFormat)
+		return nil
+	}
+	return data
+}
+
+// Int decodes a number into int.
+func Int(l *jlexer.Lexer) int { return int(signed(l, strconv.IntSize, "int")) }
+
+// Int8 decodes a number into int8.
+func Int8(l *jlexer.Lexer) int8 { return int8(signed(l, 8, "int8")) }
+
+// Int16 decodes a number into int16.
+func Int16(l *jlexer.Lexer) int16 { return int16(signed(l, 16, "int16")) }
+
+// Int32 decodes a number into int32.
+func Int32(l *jlexer.Lexer) int32 { return int32(signed(l, 32, "int32")) }
+
+// Int64 decodes a number into int64.
+func Int64(l *jlexer.Lexer) int64 { return signed(l, 64, "int64") }
+
+// Uint decodes a number into uint.
+func Uint(l *jlexer.Lexer) uint { return uint(unsigned(l, strconv.IntSize, "uint")) }
+
+// Uint8 decodes a number into uint8.
+func Uint8(l *jlexer.Lexer) uint8 { return uint8(unsigned(l, 8, "uint8")) }
+
+// Uint16 decodes a number into uint16.
+func Uint16(l *jlexer.Lexer) uint16 { return uint16(unsigned(l, 16, "uint16")) }
+
+// Uint32 decodes a number into uint32.
+func Uint32(l *jlexer.Lexer) uint32 { return uint32(unsigned(l, 32, "uint32")) }
+
+// Uint64 decodes a number into uint64.
+func Uint64(l *jlexer.Lexer) uint64 { return unsigned(l, 64, "uint64") }
+
+// IntStr decodes a string holding a number into int.
+func IntStr(l *jlexer.Lexer) int { return int(signedStr(l, strconv.IntSize, "int")) }
+
+// Int8Str decodes a string holding a number into int8.
+func Int8Str(l *jlexer.Lexer) int8 { return int8(signedStr(l, 8, "int8")) }
+
+// Int16Str decodes a string holding a number into int16.
+func Int16Str(l *jlexer.Lexer) int16 { return int16(signedStr(l, 16, "int16")) }
+
+// Int32Str decodes a string holding a number into int32.
+func Int32Str(l *jlexer.Lexer) int32 { return int32(signedStr(l, 32, "int32")) }
+
+// Int64Str decodes a string holding a number into int64.
+func Int64Str(l *jlexer.Lexer) int64 { return signedStr(l, 64, "int64") }
+
+// UintStr decodes a string holding a number into uint.
+func UintStr(l *jlexer.Lexer) uint { return uint(unsignedStr(l, strconv.IntSize, "uint")) }
+
+// Uint8Str decodes a string holding a number into uint8.
+func Uint8Str(l *jlexer.Lexer) uint8 { return uint8(unsignedStr(l, 8, "uint8")) }
+
+// Uint16Str decodes a string holding a number into uint16.
+func Uint16Str(l *jlexer.Lexer) uint16 { return uint16(unsignedStr(l, 16, "uint16")) }
+
+// Uint32Str decodes a string holding a number into uint32.
+func Uint32Str(l *jlexer.Lexer) uint32 { return uint32(unsignedStr(l, 32, "uint32")) }
+
+// Uint64Str decodes a string holding a number into uint64.
+func Uint64Str(l *jlexer.Lexer) uint64 { return unsignedStr(l, 64, "uint64") }
+
+// Float32 decodes a number into float32.
+func Float32(l *jlexer.Lexer) float32 { return float32(float(l, 32, "float32")) }
+
+// Float64 decodes a number into float64.
+func Float64(l *jlexer.Lexer) float64 { return float(l, 64, "float64") }
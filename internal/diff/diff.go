@@ -0,0 +1,141 @@
+// Package diff compares the fields of two payloads, for the packages that compute patches between them.
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/number"
+	"github.com/mailru/easyjson"
+)
+
+var (
+	jsonMarshaler     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	easyjsonMarshaler = reflect.TypeOf((*easyjson.Marshaler)(nil)).Elem()
+)
+
+// Struct returns the struct held by a present field, through containers and pointers, and whether there is one
+// whose fields should be compared one by one. Structs with a custom encoding, such as time.Time, are compared whole,
+// but the marshalers generated by easyjson are not considered custom.
+func Struct(v reflect.Value) (reflect.Value, bool) {
+	for {
+		switch {
+		case v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface:
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		case v.Kind() != reflect.Struct:
+			return reflect.Value{}, false
+		default:
+			if kind, _ := fields.Container(v.Type()); kind != fields.Plain {
+				v = v.FieldByName("Value")
+				continue
+			}
+			if Custom(v.Type()) {
+				return reflect.Value{}, false
+			}
+			return v, true
+		}
+	}
+}
+
+// Custom determines whether the struct type t has a custom encoding, other than the marshalers generated by easyjson.
+func Custom(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonMarshaler) && !pt.Implements(easyjsonMarshaler)
+}
+
+// Encode returns the JSON encoding of a field, using easyjson if it implements its marshaler interface.
+func Encode(v reflect.Value) ([]byte, error) {
+	if m, ok := v.Interface().(easyjson.Marshaler); ok {
+		return easyjson.Marshal(m)
+	}
+	return json.Marshal(v.Interface())
+}
+
+// Exposed is like Encode, but the secrets held by the field are written with their value instead of being redacted,
+// so that they can be compared and carried by a patch. The secrets held by values without an easyjson marshaler,
+// such as the items of a slice of structs without generated code, are still redacted.
+func Exposed(v reflect.Value) ([]byte, error) {
+	if m, ok := v.Interface().(easyjson.Marshaler); ok {
+		return payloads.Encoder{ExposeSecrets: true}.Marshal(m)
+	}
+	return json.Marshal(v.Interface())
+}
+
+// Equal determines whether two fields have equal JSON encodings, secrets included, see Exposed and EqualJSON.
+func Equal(a, b reflect.Value) bool {
+	da, err := Exposed(a)
+	if err != nil {
+		return false
+	}
+	db, err := Exposed(b)
+	if err != nil {
+		return false
+	}
+	return EqualJSON(da, db)
+}
+
+// EqualJSON determines whether two JSON values are equal: numbers are equal if their values are,
+// and objects regardless of the order of their members.
+func EqualJSON(a, b []byte) bool {
+	va, err := decodeJSON(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decodeJSON(b)
+	if err != nil {
+		return false
+	}
+	return equalValues(va, vb)
+}
+
+func decodeJSON(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	err := d.Decode(&v)
+	return v, err
+}
+
+func equalValues(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		ra, errA := number.Rat(a.String())
+		rb, errB := number.Rat(b.String())
+		return errA == nil && errB == nil && ra.Cmp(rb) == 0
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalValues(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, va := range a {
+			vb, ok := b[k]
+			if !ok || !equalValues(va, vb) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
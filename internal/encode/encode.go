@@ -0,0 +1,65 @@
+// Package encode implements the encoding of primitive values that need more care than the jwriter methods.
+package encode
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/internal/scope"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// nonFinite writes NaN or an infinite value according to the payloads.Encoder writing to w, if any.
+func nonFinite(w *jwriter.Writer, n float64) {
+	mode := payloads.NonFiniteError
+	if e, _ := scope.Lookup(w).(*payloads.Encoder); e != nil {
+		mode = e.NonFinite
+	}
+	switch mode {
+	case payloads.NonFiniteNull:
+		w.RawString("null")
+	case payloads.NonFiniteString:
+		switch {
+		case math.IsNaN(n):
+			w.String("NaN")
+		case n > 0:
+			w.String("Infinity")
+		default:
+			w.String("-Infinity")
+		}
+	default:
+		if w.Error == nil {
+			w.Error = fmt.Errorf("%w: %v", payloads.ErrNonFinite, n)
+		}
+	}
+}
+
+// Exposed determines whether the payloads.Encoder writing to w, if any, exposes the secrets.
+func Exposed(w *jwriter.Writer) bool {
+	e, _ := scope.Lookup(w).(*payloads.Encoder)
+	return e != nil && e.ExposeSecrets
+}
+
+// Float32 writes a float32, see payloads.Encoder for NaN and infinite values.
+func Float32(w *jwriter.Writer, n float32) {
+	if math.IsNaN(float64(n)) || math.IsInf(float64(n), 0) {
+		nonFinite(w, float64(n))
+	} else {
+		w.Float32(n)
+	}
+}
+
+// Float64 writes a float64, see payloads.Encoder for NaN and infinite values.
+func Float64(w *jwriter.Writer, n float64) {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		nonFinite(w, n)
+	} else {
+		w.Float64(n)
+	}
+}
+
+// Same determines whether both floats are equal, treating NaN as equal to itself since it has a single encoding.
+func Same[F float32 | float64](a, b F) bool {
+	return a == b || a != a && b != b
+}
@@ -0,0 +1,190 @@
+// Package fields describes how Go types map to JSON, for the packages that walk payloads by reflection.
+package fields
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const modulePath = "github.com/binadel/payloads"
+
+// Kind tells whether a type is one of the payload containers.
+type Kind int
+
+const (
+	// Plain is any type that is not a payload container.
+	Plain Kind = iota
+
+	// Optional is a container from the optional package, which can be undefined, null or present.
+	Optional
+
+	// Nullable is a container from the nullable package, which can be null or present.
+	Nullable
+)
+
+// Field is an exported struct field that is encoded as a JSON object member.
+type Field struct {
+	// Name is the member name.
+	Name string
+
+	// OmitEmpty tells whether the json tag has the omitempty option.
+	OmitEmpty bool
+
+	// Index is the index sequence of the field, for reflect.Value.FieldByIndex.
+	Index []int
+
+	// Type is the type of the field.
+	Type reflect.Type
+
+	// Tag is the tag of the field.
+	Tag reflect.StructTag
+}
+
+// Container returns the kind of container t is, and the type of its Value field.
+// For plain types it returns t itself.
+func Container(t reflect.Type) (Kind, reflect.Type) {
+	if t.Kind() != reflect.Struct {
+		return Plain, t
+	}
+	var kind Kind
+	switch t.PkgPath() {
+	case modulePath + "/optional":
+		kind = Optional
+	case modulePath + "/nullable":
+		kind = Nullable
+	default:
+		return Plain, t
+	}
+	value, ok := t.FieldByName("Value")
+	if !ok {
+		return Plain, t
+	}
+	return kind, value.Type
+}
+
+var cache sync.Map
+
+// Of returns the fields of the struct type t, in declaration order,
+// including the fields promoted from embedded structs without a json name.
+func Of(t reflect.Type) []Field {
+	if fields, ok := cache.Load(t); ok {
+		return fields.([]Field)
+	}
+	var fields []Field
+	seen := make(map[string]bool)
+	collect(t, nil, seen, &fields)
+	cache.Store(t, fields)
+	return fields
+}
+
+// ByName returns the field of the struct type t that is encoded with the given member name.
+func ByName(t reflect.Type, name string) (Field, bool) {
+	for _, f := range Of(t) {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func collect(t reflect.Type, index []int, seen map[string]bool, fields *[]Field) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" || !hasTag {
+			name = sf.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		*fields = append(*fields, Field{
+			Name:      name,
+			OmitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			Index:     append(append([]int(nil), index...), i),
+			Type:      sf.Type,
+			Tag:       sf.Tag,
+		})
+	}
+	// Fields of embedded structs are shadowed by the fields of the outer struct.
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		collect(ft, append(append([]int(nil), index...), sf.Index...), seen, fields)
+	}
+}
+
+// Option is an option of the payload tag, such as required or default=10.
+type Option struct {
+	// Name is the part before the equal sign.
+	Name string
+
+	// Arg is the part after the equal sign, empty if there is none.
+	Arg string
+}
+
+// Options parses the payload tag of the field with ParseOptions.
+func (f Field) Options() []Option {
+	return ParseOptions(f.Tag.Get("payload"))
+}
+
+// ParseOptions parses options separated by commas, such as required,default=10.
+// A comma that is part of an argument is escaped with a backslash, as in default=a\,b.
+func ParseOptions(tag string) []Option {
+	if tag == "" {
+		return nil
+	}
+	var options []Option
+	var part strings.Builder
+	flush := func() {
+		name, arg, _ := strings.Cut(part.String(), "=")
+		if name = strings.TrimSpace(name); name != "" {
+			options = append(options, Option{Name: name, Arg: arg})
+		}
+		part.Reset()
+	}
+	for i := 0; i < len(tag); i++ {
+		switch c := tag[i]; {
+		case c == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			part.WriteByte(',')
+			i++
+		case c == ',':
+			flush()
+		default:
+			part.WriteByte(c)
+		}
+	}
+	flush()
+	return options
+}
+
+// Option returns the argument of the named option of the payload tag, and whether the option is present.
+func (f Field) Option(name string) (string, bool) {
+	for _, o := range f.Options() {
+		if o.Name == name {
+			return o.Arg, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+package fields
+
+import "reflect"
+
+// State is the JSON-level state of a field or container.
+type State int
+
+const (
+	// Undefined is the state of an optional container that is not part of the payload.
+	Undefined State = iota
+
+	// Null is the state of a null container, or a nil pointer, slice, map or interface.
+	Null
+
+	// Present is the state of a value that is not null.
+	Present
+)
+
+func (s State) String() string {
+	switch s {
+	case Undefined:
+		return "undefined"
+	case Null:
+		return "null"
+	default:
+		return "present"
+	}
+}
+
+// StateOf returns the state of a value, without unwrapping it.
+// An optional container that is not defined is undefined even if it holds a value,
+// since it is left out of the payload.
+func StateOf(v reflect.Value) State {
+	if kind, _ := Container(v.Type()); kind != Plain {
+		if d, ok := v.Interface().(interface{ IsDefined() bool }); ok && kind == Optional && !d.IsDefined() {
+			return Undefined
+		}
+		if v.MethodByName("Lookup").Call(nil)[1].Bool() {
+			return Present
+		}
+		return Null
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return Null
+		}
+	}
+	return Present
+}
+
+// Defined determines whether a value is part of the payload: it must be present,
+// or be an optional container that is defined as null.
+func Defined(v reflect.Value) bool {
+	switch StateOf(v) {
+	case Present:
+		return true
+	case Null:
+		kind, _ := Container(v.Type())
+		return kind == Optional
+	default:
+		return false
+	}
+}
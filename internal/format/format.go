@@ -0,0 +1,86 @@
+// Package format implements the fmt and slog integration shared by the payload containers,
+// which print the JSON-level meaning of a container rather than its fields.
+package format
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/binadel/payloads/internal/fields"
+)
+
+// importPath matches the import paths that %T prints for the type arguments of generic types.
+var importPath = regexp.MustCompile(`(?:[\w.-]+/)+`)
+
+// Redacted replaces the value of a secret when it is printed, logged or encoded without being exposed.
+const Redacted = "[REDACTED]"
+
+// State is the JSON-level state of a container.
+type State = fields.State
+
+// Of returns the state of a container from its flags.
+func Of(isDefined, isPresent bool) State {
+	switch {
+	case !isDefined:
+		return fields.Undefined
+	case !isPresent:
+		return fields.Null
+	default:
+		return fields.Present
+	}
+}
+
+// String returns "undefined", "null" or the value formatted with %v.
+func String(state State, value any) string {
+	switch state {
+	case fields.Undefined:
+		return "undefined"
+	case fields.Null:
+		return "null"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// GoString returns the expression that creates the container with the helpers of its package,
+// such as optional.Of[optional.Int](5).
+func GoString(container any, state State, value any) string {
+	typeName := importPath.ReplaceAllString(fmt.Sprintf("%T", container), "")
+	pkg, _, _ := strings.Cut(typeName, ".")
+	switch state {
+	case fields.Undefined:
+		return fmt.Sprintf("%s.Undefined[%s]()", pkg, typeName)
+	case fields.Null:
+		return fmt.Sprintf("%s.Null[%s]()", pkg, typeName)
+	default:
+		return fmt.Sprintf("%s.Of[%s](%#v)", pkg, typeName, value)
+	}
+}
+
+// Format implements fmt.Formatter for a container: %#v prints GoString,
+// other verbs print "undefined" or "null", or the value formatted with the same verb and flags.
+func Format(f fmt.State, verb rune, container any, state State, value any) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, GoString(container, state, value))
+	case state == fields.Present:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), value)
+	default:
+		fmt.Fprint(f, String(state, value))
+	}
+}
+
+// LogValue implements slog.LogValuer for a container: an undefined container is an empty group,
+// which handlers omit, a null container is nil, and a present container is its value.
+func LogValue(state State, value any) slog.Value {
+	switch state {
+	case fields.Undefined:
+		return slog.GroupValue()
+	case fields.Null:
+		return slog.AnyValue(nil)
+	default:
+		return slog.AnyValue(value)
+	}
+}
@@ -0,0 +1,19 @@
+// Package jsonpointer implements the escaping of JSON Pointer reference tokens, as defined by RFC 6901.
+package jsonpointer
+
+import "strings"
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// Escape escapes a reference token, so that it can be appended to a pointer after a slash.
+func Escape(token string) string {
+	return escaper.Replace(token)
+}
+
+// Unescape returns the member name or index of an escaped reference token.
+func Unescape(token string) string {
+	return unescaper.Replace(token)
+}
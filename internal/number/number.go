@@ -0,0 +1,138 @@
+// Package number implements the conversions shared by the Number containers.
+package number
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxExponent bounds the exponent accepted by the exact conversions,
+// so that a short literal such as 1e999999999 cannot exhaust memory.
+const maxExponent = 10000
+
+// ErrEmpty is returned when converting an empty literal, i.e. a null Number.
+var ErrEmpty = errors.New("number: empty literal")
+
+// Valid reports whether s is a number literal as defined by the JSON grammar.
+func Valid(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		if i == len(s) || s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if i == len(s) || s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+	}
+	return i == len(s)
+}
+
+// Rat converts the literal to an exact rational number.
+func Rat(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, ErrEmpty
+	}
+	if !Valid(s) {
+		return nil, fmt.Errorf("number: invalid literal %q", s)
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, fmt.Errorf("number: exponent of %s is out of range", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("number: invalid literal %q", s)
+	}
+	return r, nil
+}
+
+// Int converts the literal to an exact integer, failing if it has a fractional part.
+func Int(s string) (*big.Int, error) {
+	r, err := Rat(s)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("number: %s is not an integer", s)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// Int64 converts the literal to an int64, failing on fractions and overflow.
+func Int64(s string) (int64, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	i, err := Int(s)
+	if err != nil {
+		return 0, err
+	}
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("number: %s overflows int64", s)
+	}
+	return i.Int64(), nil
+}
+
+// Float64 converts the literal to the nearest float64, failing if it is out of range.
+func Float64(s string) (float64, error) {
+	if s == "" {
+		return 0, ErrEmpty
+	}
+	if !Valid(s) {
+		return 0, fmt.Errorf("number: invalid literal %q", s)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("number: %s overflows float64", s)
+	}
+	return f, nil
+}
+
+// Decimal converts the literal to an unscaled integer with the given number of fractional digits,
+// so that 12.34 with scale 2 yields 1234. It fails if the conversion would lose precision.
+func Decimal(s string, scale int) (*big.Int, error) {
+	r, err := Rat(s)
+	if err != nil {
+		return nil, err
+	}
+	if scale < 0 {
+		return nil, fmt.Errorf("number: negative scale %d", scale)
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	r.Mul(r, new(big.Rat).SetInt(factor))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("number: %s does not fit in %d decimal places", s, scale)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
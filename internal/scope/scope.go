@@ -0,0 +1,33 @@
+// Package scope attaches the options of a payloads.Decoder or payloads.Encoder to the lexer or writer it creates,
+// since the containers only receive the lexer or writer from the code generated by easyjson.
+package scope
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// bound is the number of attached values, so that Lookup is free when no options are in use.
+	bound  atomic.Int64
+	values sync.Map
+)
+
+// Bind attaches value to key, a *jlexer.Lexer or a *jwriter.Writer, until release is called.
+func Bind(key, value any) (release func()) {
+	values.Store(key, value)
+	bound.Add(1)
+	return func() {
+		values.Delete(key)
+		bound.Add(-1)
+	}
+}
+
+// Lookup returns the value attached to key, or nil if there is none.
+func Lookup(key any) any {
+	if bound.Load() == 0 {
+		return nil
+	}
+	v, _ := values.Load(key)
+	return v
+}
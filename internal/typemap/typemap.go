@@ -0,0 +1,234 @@
+// Package typemap maps Go types to the shapes of their JSON encodings,
+// which the jsonschema and typescript generators then describe in their own languages.
+package typemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/problem"
+)
+
+// Kind is the kind of JSON value a Go type is encoded as.
+type Kind int
+
+const (
+	// Any is any JSON value, such as a json.RawMessage or an interface.
+	Any Kind = iota
+
+	Boolean
+
+	// Integer is a number without a fractional part.
+	Integer
+
+	Number
+
+	String
+
+	// DateTime is a string holding an RFC 3339 date and time.
+	DateTime
+
+	// Base64 is a string holding base64 encoded data.
+	Base64
+
+	// IntegerString is a string holding an integer, such as "42".
+	IntegerString
+
+	// Array is an array of items of type Shape.Elem.
+	Array
+
+	// Map is an object with members of type Shape.Elem.
+	Map
+
+	// Struct is an object with the fields of the struct type Shape.Elem.
+	Struct
+
+	// Union is an object which is one of Shape.Variants, or any object if the union is not registered.
+	Union
+)
+
+// Shape describes the JSON encoding of a Go type.
+type Shape struct {
+	Kind Kind
+
+	// Null is set for pointers and containers, which may also be null.
+	Null bool
+
+	// Elem is the type of the items of an Array, the members of a Map, or the struct type of a Struct.
+	Elem reflect.Type
+
+	// Discriminator is the name of the member selecting the variant of a Union.
+	Discriminator string
+
+	// Variants are the variants of a registered Union, sorted by key.
+	Variants []Variant
+}
+
+// Variant is a variant of a union, whose discriminator member is set to Key.
+type Variant struct {
+	Key string
+
+	// Type is the struct type of the variant, without the pointers of the registry.
+	Type reflect.Type
+}
+
+// Registry is implemented by union.Registry, whatever its type argument.
+type Registry interface {
+	Discriminator() string
+	Variants() map[string]reflect.Type
+}
+
+type union struct {
+	discriminator string
+	variants      []Variant
+}
+
+// Mapper maps Go types to their shapes, knowing the variants of the unions added to it.
+// The zero value is ready to use.
+type Mapper struct {
+	unions map[reflect.Type]union
+}
+
+// AddUnion adds the variants of a union registry, so that the optional.Union and nullable.Union containers
+// of the same type argument are mapped to them. It reports false if registry is not a union.Registry.
+func (m *Mapper) AddUnion(registry Registry) bool {
+	method, ok := reflect.TypeOf(registry).MethodByName("New")
+	if !ok {
+		return false
+	}
+	variants := registry.Variants()
+	u := union{discriminator: registry.Discriminator(), variants: make([]Variant, 0, len(variants))}
+	for key, t := range variants {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		u.variants = append(u.variants, Variant{Key: key, Type: t})
+	}
+	sort.Slice(u.variants, func(i, j int) bool { return u.variants[i].Key < u.variants[j].Key })
+
+	if m.unions == nil {
+		m.unions = make(map[reflect.Type]union)
+	}
+	m.unions[method.Type.Out(0)] = u
+	return true
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	numberType     = reflect.TypeOf(json.Number(""))
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+	// ProblemDetails is the type of problem.Details, which the generators describe by hand.
+	ProblemDetails = reflect.TypeOf(problem.Details{})
+)
+
+// Of returns the shape of the type t.
+func (m *Mapper) Of(t reflect.Type) Shape {
+	if t == nil {
+		return Shape{Kind: Any}
+	}
+	if kind, value := fields.Container(t); kind != fields.Plain {
+		s := m.container(t, value)
+		s.Null = true
+		return s
+	}
+
+	switch t {
+	case timeType:
+		return Shape{Kind: DateTime}
+	case numberType:
+		return Shape{Kind: Number}
+	case rawMessageType:
+		return Shape{Kind: Any}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Shape{Kind: Boolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Shape{Kind: Integer}
+	case reflect.Float32, reflect.Float64:
+		return Shape{Kind: Number}
+	case reflect.String:
+		return Shape{Kind: String}
+	case reflect.Pointer:
+		s := m.Of(t.Elem())
+		s.Null = true
+		return s
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Shape{Kind: Base64}
+		}
+		return Shape{Kind: Array, Elem: t.Elem()}
+	case reflect.Map:
+		return Shape{Kind: Map, Elem: t.Elem()}
+	case reflect.Struct:
+		return Shape{Kind: Struct, Elem: t}
+	default:
+		return Shape{Kind: Any}
+	}
+}
+
+// container returns the shape of the value of a container of type t, whose Value field is of type value.
+func (m *Mapper) container(t reflect.Type, value reflect.Type) Shape {
+	name, _, _ := strings.Cut(t.Name(), "[")
+	switch {
+	case name == "Bytes":
+		return Shape{Kind: Base64}
+	case name == "Union":
+		u, ok := m.unions[value]
+		if !ok {
+			return Shape{Kind: Union}
+		}
+		return Shape{Kind: Union, Discriminator: u.discriminator, Variants: u.variants}
+	case strings.HasSuffix(name, "String") && value.Kind() != reflect.String:
+		// The integer is encoded as a string, such as "42".
+		return Shape{Kind: IntegerString}
+	case name == "Array" && value.Elem().Kind() == reflect.Pointer:
+		// The items of an Array are pointers to structs, which are not expected to be null.
+		return Shape{Kind: Array, Elem: value.Elem().Elem()}
+	case value.Kind() == reflect.Pointer:
+		// The value of an Object is a pointer, null is already accepted by the container.
+		return m.Of(value.Elem())
+	default:
+		return m.Of(value)
+	}
+}
+
+// Omissible determines whether the member of a field may be missing from a payload: an optional field may be
+// undefined, and a field with the omitempty option is left out when it is empty or null, as easyjson encodes it.
+func Omissible(f fields.Field) bool {
+	kind, _ := fields.Container(f.Type)
+	return kind == fields.Optional || f.OmitEmpty
+}
+
+var (
+	importPath = regexp.MustCompile(`[\w.-]+(/[\w.-]+)*\.`)
+	nonWord    = regexp.MustCompile(`\W+`)
+)
+
+// Name returns a name for the struct type t, such as Page_Item for Page[pkg.Item], or Object for an anonymous struct.
+// A number is appended to the name while taken reports that it is already used. problem.Details is ProblemDetails.
+func Name(t reflect.Type, taken func(name string) bool) string {
+	if t == ProblemDetails {
+		return "ProblemDetails"
+	}
+	name := t.Name()
+	if name == "" {
+		name = "Object"
+	}
+	name = importPath.ReplaceAllString(name, "")
+	name = strings.Trim(nonWord.ReplaceAllString(name, "_"), "_")
+	unique := name
+	for i := 2; taken(unique); i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
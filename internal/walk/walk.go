@@ -0,0 +1,113 @@
+// Package walk visits the fields of the structs reachable from a payload by reflection,
+// for the packages that check or filter them by their tags.
+package walk
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/jsonpointer"
+)
+
+// Path locates a field in a payload.
+type Path struct {
+	// Pointer is the JSON Pointer of the field.
+	Pointer string
+
+	// Names are the member names leading to the field, without the indexes of the array items,
+	// such as [items price] for /items/3/price.
+	Names []string
+}
+
+func (p Path) child(token string, name bool) Path {
+	child := Path{Pointer: p.Pointer + "/" + jsonpointer.Escape(token), Names: p.Names}
+	if name {
+		child.Names = append(p.Names[:len(p.Names):len(p.Names)], token)
+	}
+	return child
+}
+
+// Visitor is called for every field f of the structs reachable from a walked value, with the type of the struct
+// declaring it. The value of the field is walked in turn if it returns true, after the call, so that the visitor
+// may set it.
+type Visitor func(parent reflect.Type, f fields.Field, value reflect.Value, path Path) (bool, error)
+
+// Fields calls visit for every field of the structs held by value, descending into the values held by
+// containers, pointers and interfaces, the items of slices and arrays other than byte slices,
+// and the members of maps with string keys. It stops at the first error returned by visit.
+func Fields(value reflect.Value, visit Visitor) error {
+	return walk(value, Path{}, visit)
+}
+
+func walk(value reflect.Value, path Path, visit Visitor) error {
+	value = Held(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for _, f := range fields.Of(value.Type()) {
+			fv, err := value.FieldByIndexErr(f.Index)
+			if err != nil {
+				// The field is promoted from a nil embedded pointer.
+				continue
+			}
+			p := path.child(f.Name, true)
+			descend, err := visit(value.Type(), f, fv, p)
+			if err != nil {
+				return err
+			}
+			if !descend {
+				continue
+			}
+			if err := walk(fv, p, visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := walk(value.Index(i), path.child(strconv.Itoa(i), false), visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			if err := walk(iter.Value(), path.child(iter.Key().String(), true), visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Held returns the value that is encoded in place of value, following pointers, interfaces and the values
+// of the containers. It returns an invalid value if there is none: for undefined and null containers
+// and nil pointers and interfaces.
+func Held(value reflect.Value) reflect.Value {
+	for value.IsValid() {
+		if fields.StateOf(value) != fields.Present {
+			return reflect.Value{}
+		}
+		switch value.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			value = value.Elem()
+		case reflect.Struct:
+			if kind, _ := fields.Container(value.Type()); kind == fields.Plain {
+				return value
+			}
+			value = value.FieldByName("Value")
+		default:
+			return value
+		}
+	}
+	return value
+}
@@ -0,0 +1,207 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/jsonpointer"
+	"github.com/binadel/payloads/problem"
+	"github.com/mailru/easyjson"
+)
+
+var (
+	// ErrInvalid is reported for an operation with an unknown name or a missing member.
+	ErrInvalid = errors.New("invalid operation")
+
+	// ErrTestFailed is reported for a test operation whose value differs from the target value.
+	ErrTestFailed = errors.New("test failed")
+)
+
+// Error is the error of applying an operation of a patch.
+type Error struct {
+	// Index is the position of the operation in the patch.
+	Index int
+
+	// Member is the member of the operation that is at fault: op, path, from or value.
+	Member string
+
+	// Err is the reason of the failure: ErrInvalid, ErrTestFailed, or a jsonpointer.Error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonpatch: operation %d: %s: %v", e.Index, e.Member, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Problem returns the details of the problem of applying the patch: a conflict for a failed test,
+// and a validation problem for an invalid operation or a path that cannot be resolved.
+// The pointer of the problem refers to the member of the operation in the patch document, such as /2/path.
+func (e *Error) Problem() problem.Details {
+	errs := []problem.Error{{Detail: e.Err.Error(), Pointer: "/" + strconv.Itoa(e.Index) + "/" + e.Member}}
+	if errors.Is(e.Err, ErrTestFailed) {
+		return problem.Details{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusConflict),
+			Status: http.StatusConflict,
+			Detail: "The patch does not apply to the current state of the resource.",
+			Errors: errs,
+		}
+	}
+	return problem.Validation(errs...)
+}
+
+// Decode decodes a JSON Patch document and checks that its operations are valid.
+// A decoding error can be converted with problem.FromDecodeError, and an invalid operation is an *Error.
+func Decode(data []byte) (Patch, error) {
+	var p Patch
+	if err := easyjson.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return p, p.Validate()
+}
+
+// Validate checks that the operations have a known name and the members that they need.
+func (p Patch) Validate() error {
+	for i, o := range p {
+		if err := o.validate(); err != nil {
+			err.Index = i
+			return err
+		}
+	}
+	return nil
+}
+
+func (o Operation) validate() *Error {
+	_, hasValue := o.value()
+	switch o.Op {
+	case OpAdd, OpReplace, OpTest:
+		if !hasValue {
+			return &Error{Member: "value", Err: ErrInvalid}
+		}
+	case OpRemove:
+	case OpMove, OpCopy:
+		if _, err := jsonpointer.Parse(o.From); err != nil {
+			return &Error{Member: "from", Err: err}
+		}
+		if o.Op == OpMove && strings.HasPrefix(o.Path, o.From+"/") {
+			// A value cannot be moved into one of its children.
+			return &Error{Member: "from", Err: ErrInvalid}
+		}
+	default:
+		return &Error{Member: "op", Err: ErrInvalid}
+	}
+	if _, err := jsonpointer.Parse(o.Path); err != nil {
+		return &Error{Member: "path", Err: err}
+	}
+	return nil
+}
+
+// Apply applies the operations of the patch in order to the payload v, which must be a pointer.
+// The patch is applied atomically: the operations are applied to a deep copy of the payload,
+// which replaces the payload only if all of them succeed. Otherwise it returns an *Error.
+//
+// A value is added to an array before the item at the given index, or at its end for the index "-".
+// A struct field that is removed becomes undefined if it is optional, and null if it is nullable.
+func (p Patch) Apply(v any) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return &Error{Member: "path", Err: jsonpointer.ErrNotSettable}
+	}
+	work := reflect.New(target.Elem().Type())
+	work.Elem().Set(reflect.ValueOf(deepcopy.Copy(target.Elem().Interface())))
+	for i, o := range p {
+		if err := o.apply(work.Interface()); err != nil {
+			err.Index = i
+			return err
+		}
+	}
+	target.Elem().Set(work.Elem())
+	return nil
+}
+
+func (o Operation) apply(v any) *Error {
+	value, _ := o.value()
+	switch o.Op {
+	case OpAdd:
+		return wrap("path", jsonpointer.InsertJSON(v, o.Path, value))
+	case OpRemove:
+		if err := exists(v, o.Path); err != nil {
+			return wrap("path", err)
+		}
+		return wrap("path", jsonpointer.Unset(v, o.Path))
+	case OpReplace:
+		if err := exists(v, o.Path); err != nil {
+			return wrap("path", err)
+		}
+		return wrap("path", jsonpointer.SetJSON(v, o.Path, value))
+	case OpMove, OpCopy:
+		data, err := get(v, o.From)
+		if err != nil {
+			return wrap("from", err)
+		}
+		if o.Op == OpMove {
+			if err := jsonpointer.Unset(v, o.From); err != nil {
+				return wrap("from", err)
+			}
+		}
+		return wrap("path", jsonpointer.InsertJSON(v, o.Path, data))
+	case OpTest:
+		data, err := get(v, o.Path)
+		if err != nil {
+			return wrap("path", err)
+		}
+		if !diff.EqualJSON(data, value) {
+			return &Error{Member: "value", Err: ErrTestFailed}
+		}
+	}
+	return nil
+}
+
+func wrap(member string, err error) *Error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Member: member, Err: err}
+}
+
+// exists reports an error if there is no value at the pointer, null being a value.
+func exists(v any, pointer string) error {
+	_, state, err := jsonpointer.Get(v, pointer)
+	if err == nil && state == jsonpointer.Undefined {
+		err = &jsonpointer.Error{Pointer: pointer, Err: jsonpointer.ErrNotFound}
+	}
+	return err
+}
+
+// get returns the JSON encoding of the value at the pointer, which must exist.
+func get(v any, pointer string) ([]byte, error) {
+	value, state, err := jsonpointer.Get(v, pointer)
+	switch {
+	case err != nil:
+		return nil, err
+	case state == jsonpointer.Undefined:
+		return nil, &jsonpointer.Error{Pointer: pointer, Err: jsonpointer.ErrNotFound}
+	case state == jsonpointer.Null:
+		return []byte("null"), nil
+	}
+	if m, ok := value.(easyjson.Marshaler); ok {
+		// The secrets are copied, moved and tested with their value.
+		return payloads.Encoder{ExposeSecrets: true}.Marshal(m)
+	}
+	return json.Marshal(value)
+}
@@ -0,0 +1,137 @@
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/binadel/payloads/jsonpatch"
+	"github.com/binadel/payloads/optional"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+type tag struct {
+	Name string
+}
+
+func (v *tag) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"name":`)
+	w.String(v.Name)
+	w.RawByte('}')
+}
+
+func (v *tag) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if key == "name" {
+			v.Name = l.String()
+		} else {
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+type post struct {
+	Title  optional.String       `json:"title"`
+	Tags   optional.Array[*tag]  `json:"tags"`
+	Author optional.Object[*tag] `json:"author"`
+}
+
+func TestApplyWithoutNew(t *testing.T) {
+	// The patch comes from a client, and the containers have no New function.
+	patch, err := jsonpatch.Decode([]byte(`[
+		{"op":"add","path":"/tags","value":[{"name":"go"},null]},
+		{"op":"add","path":"/author","value":{"name":"ada"}}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p post
+	if err := patch.Apply(&p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Tags.Value) != 2 || p.Tags.Value[0].Name != "go" || p.Tags.Value[1] != nil {
+		t.Errorf("tags = %+v, want go and null", p.Tags.Value)
+	}
+	if p.Author.Value == nil || p.Author.Value.Name != "ada" {
+		t.Errorf("author = %+v, want ada", p.Author.Value)
+	}
+	if p.Tags.New != nil || p.Author.New != nil {
+		t.Error("the New functions were left set")
+	}
+}
+
+func TestApplyRootKeepsNew(t *testing.T) {
+	newTag := func() *tag { return &tag{Name: "new"} }
+	p := post{Tags: optional.Array[*tag]{New: newTag}, Author: optional.Object[*tag]{New: newTag}}
+
+	patch, err := jsonpatch.Decode([]byte(`[{"op":"replace","path":"","value":{"title":"hello","tags":[{}]}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := patch.Apply(&p); err != nil {
+		t.Fatal(err)
+	}
+	if title, _ := p.Title.Lookup(); title != "hello" {
+		t.Errorf("title = %q, want hello", title)
+	}
+	if p.Tags.New == nil || p.Author.New == nil {
+		t.Fatal("replacing the root dropped the New functions")
+	}
+	if len(p.Tags.Value) != 1 || p.Tags.Value[0].Name != "new" {
+		t.Errorf("tags = %+v, want one item made by New", p.Tags.Value)
+	}
+	if p.Author.IsDefined() {
+		t.Errorf("author = %+v, want undefined", p.Author)
+	}
+}
+
+type credentials struct {
+	Password optional.Secret `json:"password"`
+}
+
+func (v *credentials) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"password":`)
+	v.Password.MarshalEasyJSON(w)
+	w.RawByte('}')
+}
+
+func (v *credentials) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if key == "password" {
+			v.Password.UnmarshalEasyJSON(l)
+		} else {
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+func TestApplyCopySecret(t *testing.T) {
+	type account struct {
+		Current  optional.Object[*credentials] `json:"current"`
+		Previous optional.Object[*credentials] `json:"previous"`
+	}
+	a := account{Current: optional.Of[optional.Object[*credentials]](&credentials{Password: optional.Of[optional.Secret]("hunter2")})}
+
+	patch, err := jsonpatch.Decode([]byte(`[
+		{"op":"copy","from":"/current","path":"/previous"},
+		{"op":"test","path":"/previous/password","value":"hunter2"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := patch.Apply(&a); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := a.Previous.Value.Password.Lookup(); got != "hunter2" {
+		t.Errorf("copied password = %q, want hunter2", got)
+	}
+}
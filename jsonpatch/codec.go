@@ -0,0 +1,112 @@
+package jsonpatch
+
+import (
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// The codec is written by hand rather than generated, because easyjson skips null members
+// before the Value container can tell a null value from a missing one.
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (p Patch) MarshalEasyJSON(w *jwriter.Writer) {
+	if p == nil {
+		w.RawString("null")
+		return
+	}
+	w.RawByte('[')
+	for i, o := range p {
+		if i > 0 {
+			w.RawByte(',')
+		}
+		o.MarshalEasyJSON(w)
+	}
+	w.RawByte(']')
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (p *Patch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*p = nil
+		return
+	}
+	*p = Patch{}
+	l.Delim('[')
+	for !l.IsDelim(']') {
+		var o Operation
+		o.UnmarshalEasyJSON(l)
+		*p = append(*p, o)
+		l.WantComma()
+	}
+	l.Delim(']')
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (p Patch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	p.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (p *Patch) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	p.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (o Operation) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"op":`)
+	w.String(o.Op)
+	w.RawString(`,"path":`)
+	w.String(o.Path)
+	if o.Op == OpMove || o.Op == OpCopy {
+		w.RawString(`,"from":`)
+		w.String(o.From)
+	}
+	if o.Value.IsDefined() || o.Value.Value != nil {
+		w.RawString(`,"value":`)
+		o.Value.MarshalEasyJSON(w)
+	}
+	w.RawByte('}')
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (o *Operation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	*o = Operation{}
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		name := l.UnsafeFieldName(false)
+		l.WantColon()
+		switch name {
+		case "op":
+			o.Op = l.String()
+		case "path":
+			o.Path = l.String()
+		case "from":
+			o.From = l.String()
+		case "value":
+			o.Value.UnmarshalEasyJSON(l)
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	o.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	o.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
@@ -0,0 +1,67 @@
+package jsonpatch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/jsonpointer"
+	"github.com/binadel/payloads/optional"
+)
+
+// Diff returns the operations that turn the payload from into the payload to, which are structs, or pointers
+// to structs, of the same type. A field that is undefined in either payload is not part of the comparison.
+// Nested structs, such as the value of an optional.Object, are compared field by field, and the other fields
+// are compared by their JSON encoding and replaced whole, so the operations are all replace operations.
+// Secrets are compared and written with their value, so the patch must be handled as sensitive as the payloads.
+func Diff(from, to any) (Patch, error) {
+	a, okA := diff.Struct(reflect.ValueOf(from))
+	b, okB := diff.Struct(reflect.ValueOf(to))
+	if !okA || !okB || a.Type() != b.Type() {
+		return nil, fmt.Errorf("jsonpatch: cannot diff %T and %T", from, to)
+	}
+	p := Patch{}
+	if err := diffStruct(a, b, "", &p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func diffStruct(a, b reflect.Value, pointer string, p *Patch) error {
+	for _, f := range fields.Of(a.Type()) {
+		fa, errA := a.FieldByIndexErr(f.Index)
+		fb, errB := b.FieldByIndexErr(f.Index)
+		if errA != nil || errB != nil {
+			// The field is promoted from a nil embedded pointer.
+			continue
+		}
+		sa, sb := fields.StateOf(fa), fields.StateOf(fb)
+		if sa == fields.Undefined || sb == fields.Undefined {
+			continue
+		}
+		path := pointer + "/" + jsonpointer.Escape(f.Name)
+		if sa == fields.Present && sb == fields.Present {
+			na, okA := diff.Struct(fa)
+			nb, okB := diff.Struct(fb)
+			if okA && okB && na.Type() == nb.Type() {
+				if err := diffStruct(na, nb, path, p); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if diff.Equal(fa, fb) {
+			continue
+		}
+		data, err := diff.Exposed(fb)
+		if err != nil {
+			return err
+		}
+		var value optional.Raw
+		value.Set(data)
+		value.SetDefined(true)
+		*p = append(*p, Operation{Op: OpReplace, Path: path, Value: value})
+	}
+	return nil
+}
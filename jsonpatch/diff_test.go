@@ -0,0 +1,50 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/binadel/payloads/jsonpatch"
+	"github.com/binadel/payloads/optional"
+)
+
+type account struct {
+	Name     optional.String `json:"name"`
+	Password optional.Secret `json:"password"`
+}
+
+func TestDiffSecret(t *testing.T) {
+	from := account{Name: optional.Of[optional.String]("ada"), Password: optional.Of[optional.Secret]("hunter2")}
+	tests := []struct {
+		name string
+		to   account
+		want string
+	}{
+		{"Unchanged", from, `[]`},
+		{"Changed", account{Name: from.Name, Password: optional.Of[optional.Secret]("hunter3")},
+			`[{"op":"replace","path":"/password","value":"hunter3"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := jsonpatch.Diff(&from, &tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Diff() = %s, want %s", data, tt.want)
+			}
+
+			got := from
+			if err := patch.Apply(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Password.Equal(tt.to.Password) {
+				t.Errorf("applying the patch gives the password %#v, want %#v", got.Password.Value, tt.to.Password.Value)
+			}
+		})
+	}
+}
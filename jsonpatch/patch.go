@@ -0,0 +1,44 @@
+// Package jsonpatch applies JSON Patch documents, as defined by RFC 6902, to payloads made of structs
+// with optional and nullable fields, addressing their values with the jsonpointer package.
+package jsonpatch
+
+import "github.com/binadel/payloads/optional"
+
+// MIMEJSONPatch is the media type of a JSON Patch document.
+const MIMEJSONPatch = "application/json-patch+json"
+
+// The operations of a JSON Patch document.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// Patch is a JSON Patch document, a list of operations applied in order.
+type Patch []Operation
+
+// Operation is an operation of a JSON Patch document.
+type Operation struct {
+	// Op is the name of the operation, such as add.
+	Op string `json:"op"`
+
+	// Path is the JSON Pointer of the target value.
+	Path string `json:"path"`
+
+	// From is the JSON Pointer of the source value of the move and copy operations.
+	From string `json:"from,omitempty"`
+
+	// Value is the JSON value of the add, replace and test operations, undefined if the member is missing.
+	Value optional.Raw `json:"value,omitempty"`
+}
+
+// value returns the JSON value of the operation, and false if the member is missing.
+func (o Operation) value() ([]byte, bool) {
+	if raw, ok := o.Value.Lookup(); ok {
+		return raw, true
+	}
+	return []byte("null"), o.Value.IsDefined()
+}
@@ -0,0 +1,107 @@
+// Package jsonpointer resolves RFC 6901 JSON Pointers, such as /items/3/price, against payloads made of structs,
+// the containers of the optional and nullable packages, slices, arrays and maps with string keys.
+// Struct fields are addressed by their JSON member name.
+package jsonpointer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/binadel/payloads/internal/fields"
+	escape "github.com/binadel/payloads/internal/jsonpointer"
+)
+
+var (
+	// ErrSyntax is reported for a pointer that does not start with a slash, or an index that is not a number.
+	ErrSyntax = errors.New("invalid pointer")
+
+	// ErrNotFound is reported for a pointer that goes through a member, item or field that does not exist,
+	// or through a value that is not an object or an array.
+	ErrNotFound = errors.New("value not found")
+
+	// ErrNotSettable is reported for a pointer that cannot be modified because the payload is not passed by pointer.
+	ErrNotSettable = errors.New("value cannot be set")
+
+	// ErrType is reported when the new value does not fit the value at the pointer.
+	ErrType = errors.New("value does not fit")
+)
+
+// Error is the error of resolving a pointer against a payload.
+type Error struct {
+	// Pointer is the part of the pointer that could be resolved, followed by the reference token that failed.
+	Pointer string
+
+	// Err is the reason of the failure, one of ErrSyntax, ErrNotFound, ErrNotSettable or ErrType,
+	// possibly wrapping the decoding error of the new value.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonpointer: %s: %v", e.Pointer, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// State is the JSON-level state of the value at a pointer: Undefined, Null or Present.
+type State = fields.State
+
+const (
+	// Undefined is the state of an optional container that is not part of the payload, or a missing map key.
+	Undefined = fields.Undefined
+
+	// Null is the state of a null container, or a nil pointer, slice, map or interface.
+	Null = fields.Null
+
+	// Present is the state of a value that is not null.
+	Present = fields.Present
+)
+
+// Parse returns the unescaped reference tokens of a pointer. The empty pointer refers to the whole payload.
+func Parse(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, &Error{Pointer: pointer, Err: ErrSyntax}
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = escape.Unescape(token)
+	}
+	return tokens, nil
+}
+
+// Format returns the pointer made of the given reference tokens, escaping them.
+func Format(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escape.Escape(token))
+	}
+	return b.String()
+}
+
+// Escape escapes a reference token, so that it can be appended to a pointer after a slash.
+func Escape(token string) string {
+	return escape.Escape(token)
+}
+
+// index parses the reference token of an array item, which has no leading zeros, for an array of length n.
+// The token "-", which refers to the position after the last item, results in n.
+func index(token string, n int) (int, error) {
+	if token == "-" {
+		return n, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, ErrSyntax
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, ErrSyntax
+	}
+	return i, nil
+}
@@ -0,0 +1,402 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/mailru/easyjson"
+)
+
+// Get returns the value at the pointer in the payload v, and its state. For a container, the value is the one
+// returned by its Lookup method, and otherwise the Go value itself. It is nil if the state is not Present.
+// A missing map key is undefined.
+func Get(v any, pointer string) (any, State, error) {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return nil, Undefined, err
+	}
+	cur := reflect.ValueOf(v)
+	for i, token := range tokens {
+		held, state := unwrap(cur)
+		if state != Present {
+			return nil, Undefined, &Error{Pointer: Format(tokens[:i+1]...), Err: ErrNotFound}
+		}
+		if held.Kind() == reflect.Map && i == len(tokens)-1 {
+			if key, ok := mapKey(held, token); ok && !held.MapIndex(key).IsValid() {
+				return nil, Undefined, nil
+			}
+		}
+		if cur, err = child(held, token); err != nil {
+			return nil, Undefined, &Error{Pointer: Format(tokens[:i+1]...), Err: err}
+		}
+	}
+	if !cur.IsValid() {
+		return nil, Null, nil
+	}
+	if s := fields.StateOf(cur); s != Present {
+		return nil, s, nil
+	}
+	if kind, _ := fields.Container(cur.Type()); kind != fields.Plain {
+		value := cur.MethodByName("Lookup").Call(nil)
+		return value[0].Interface(), Present, nil
+	}
+	return cur.Interface(), Present, nil
+}
+
+// Set replaces the value at the pointer in the payload v, which must be a pointer, with value.
+// A value of the same type as the target is assigned as is, any other value is encoded as JSON
+// and decoded into the target as by SetJSON.
+func Set(v any, pointer string, value any) error {
+	return put(v, pointer, false, func(target reflect.Value) error {
+		if value != nil && reflect.TypeOf(value) == target.Type() {
+			target.Set(reflect.ValueOf(value))
+			return nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return decode(target, data)
+	})
+}
+
+// SetJSON decodes data into the value at the pointer in the payload v, which must be a pointer.
+// The value is replaced rather than merged, but a container keeps its configuration, such as the New function
+// of an optional.Object, and so do the containers of a struct. An optional.Object or optional.Array without
+// a New function allocates its value or items itself. The token "-", or the length of an array, appends an item
+// to the array.
+// A missing map key is added, but the other parents of the value must exist.
+func SetJSON(v any, pointer string, data []byte) error {
+	return put(v, pointer, false, func(target reflect.Value) error {
+		return decode(target, data)
+	})
+}
+
+// InsertJSON is like SetJSON, except that an item addressed in an array is inserted before the existing one,
+// as with the add operation of JSON Patch.
+func InsertJSON(v any, pointer string, data []byte) error {
+	return put(v, pointer, true, func(target reflect.Value) error {
+		return decode(target, data)
+	})
+}
+
+// Unset removes the value at the pointer in the payload v, which must be a pointer: an optional container
+// becomes undefined, a nullable container null, and any other field its zero value. An array item is removed,
+// shifting the following items, and a map key is deleted.
+func Unset(v any, pointer string) error {
+	return modify(v, pointer, func(parent reflect.Value, token string) error {
+		switch parent.Kind() {
+		case reflect.Struct:
+			f, ok := fields.ByName(parent.Type(), token)
+			if !ok {
+				return ErrNotFound
+			}
+			target, err := parent.FieldByIndexErr(f.Index)
+			if err != nil {
+				return ErrNotFound
+			}
+			reset(target, false)
+		case reflect.Slice:
+			i, err := index(token, parent.Len())
+			if err != nil {
+				return err
+			}
+			if i >= parent.Len() {
+				return ErrNotFound
+			}
+			reflect.Copy(parent.Slice(i, parent.Len()), parent.Slice(i+1, parent.Len()))
+			parent.Index(parent.Len() - 1).SetZero()
+			parent.SetLen(parent.Len() - 1)
+		case reflect.Map:
+			key, ok := mapKey(parent, token)
+			if !ok || !parent.MapIndex(key).IsValid() {
+				return ErrNotFound
+			}
+			parent.SetMapIndex(key, reflect.Value{})
+		default:
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// put stores a value at the pointer with assign, which sets the target it is given.
+func put(v any, pointer string, insert bool, assign func(target reflect.Value) error) error {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		root := reflect.ValueOf(v)
+		if root.Kind() != reflect.Pointer || root.IsNil() {
+			return &Error{Pointer: pointer, Err: ErrNotSettable}
+		}
+		if err := assign(root.Elem()); err != nil {
+			return &Error{Pointer: pointer, Err: err}
+		}
+		return nil
+	}
+	return modify(v, pointer, func(parent reflect.Value, token string) error {
+		switch parent.Kind() {
+		case reflect.Struct:
+			f, ok := fields.ByName(parent.Type(), token)
+			if !ok {
+				return ErrNotFound
+			}
+			target, err := parent.FieldByIndexErr(f.Index)
+			if err != nil {
+				return ErrNotFound
+			}
+			return assign(target)
+		case reflect.Slice, reflect.Array:
+			n := parent.Len()
+			i, err := index(token, n)
+			if err != nil {
+				return err
+			}
+			if i > n || (parent.Kind() == reflect.Array && (i == n || insert)) {
+				// An array cannot grow.
+				return ErrNotFound
+			}
+			if i < n && !insert {
+				return assign(parent.Index(i))
+			}
+			item := reflect.New(parent.Type().Elem()).Elem()
+			if err := assign(item); err != nil {
+				return err
+			}
+			parent.Set(reflect.Append(parent, item))
+			reflect.Copy(parent.Slice(i+1, n+1), parent.Slice(i, n))
+			parent.Index(i).Set(item)
+			return nil
+		case reflect.Map:
+			key, ok := mapKey(parent, token)
+			if !ok {
+				return ErrNotFound
+			}
+			item := reflect.New(parent.Type().Elem()).Elem()
+			if err := assign(item); err != nil {
+				return err
+			}
+			if parent.IsNil() {
+				parent.Set(reflect.MakeMap(parent.Type()))
+			}
+			parent.SetMapIndex(key, item)
+			return nil
+		default:
+			return ErrNotFound
+		}
+	})
+}
+
+// modify resolves the parent of the value at the pointer and calls op with it and the last reference token.
+// Map items, which are not addressable, are copied and stored back after op.
+func modify(v any, pointer string, op func(parent reflect.Value, token string) error) error {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return &Error{Pointer: pointer, Err: ErrNotFound}
+	}
+	root := reflect.ValueOf(v)
+	if root.Kind() != reflect.Pointer || root.IsNil() {
+		return &Error{Pointer: pointer, Err: ErrNotSettable}
+	}
+	return descend(root, tokens, 0, op)
+}
+
+func descend(cur reflect.Value, tokens []string, i int, op func(parent reflect.Value, token string) error) error {
+	fail := func(err error) error {
+		return &Error{Pointer: Format(tokens[:i+1]...), Err: err}
+	}
+	held, state := unwrap(cur)
+	if state == Null && cur.Kind() == reflect.Map && i == len(tokens)-1 {
+		// A nil map is allocated to add a key.
+		held, state = cur, Present
+	}
+	if state != Present {
+		return fail(ErrNotFound)
+	}
+	if !held.CanSet() && held.Kind() != reflect.Map {
+		return fail(ErrNotSettable)
+	}
+	if i == len(tokens)-1 {
+		if err := op(held, tokens[i]); err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				return err
+			}
+			return fail(err)
+		}
+		return nil
+	}
+
+	next, err := child(held, tokens[i])
+	if err != nil {
+		return fail(err)
+	}
+	if held.Kind() != reflect.Map {
+		return descend(next, tokens, i+1, op)
+	}
+	key, ok := mapKey(held, tokens[i])
+	if !ok {
+		return fail(ErrNotFound)
+	}
+	item := reflect.New(next.Type()).Elem()
+	item.Set(next)
+	if err := descend(item, tokens, i+1, op); err != nil {
+		return err
+	}
+	held.SetMapIndex(key, item)
+	return nil
+}
+
+// child returns the field, item or map value of held addressed by token.
+func child(held reflect.Value, token string) (reflect.Value, error) {
+	switch held.Kind() {
+	case reflect.Struct:
+		f, ok := fields.ByName(held.Type(), token)
+		if !ok {
+			return reflect.Value{}, ErrNotFound
+		}
+		value, err := held.FieldByIndexErr(f.Index)
+		if err != nil {
+			return reflect.Value{}, ErrNotFound
+		}
+		return value, nil
+	case reflect.Slice, reflect.Array:
+		if held.Type().Elem().Kind() == reflect.Uint8 {
+			// A byte slice is encoded as a string.
+			return reflect.Value{}, ErrNotFound
+		}
+		i, err := index(token, held.Len())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if i >= held.Len() {
+			return reflect.Value{}, ErrNotFound
+		}
+		return held.Index(i), nil
+	case reflect.Map:
+		key, ok := mapKey(held, token)
+		if !ok {
+			return reflect.Value{}, ErrNotFound
+		}
+		value := held.MapIndex(key)
+		if !value.IsValid() {
+			return reflect.Value{}, ErrNotFound
+		}
+		return value, nil
+	default:
+		return reflect.Value{}, ErrNotFound
+	}
+}
+
+// mapKey converts token to the key type of the map m. It reports false unless the keys are strings,
+// since the other keys are not member names that a pointer can address.
+func mapKey(m reflect.Value, token string) (reflect.Value, bool) {
+	if m.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(token).Convert(m.Type().Key()), true
+}
+
+// unwrap returns the value held by a container, pointer or interface, and its state.
+func unwrap(value reflect.Value) (reflect.Value, State) {
+	for {
+		if !value.IsValid() {
+			return value, Null
+		}
+		switch s := fields.StateOf(value); {
+		case s != Present:
+			return reflect.Value{}, s
+		case value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface:
+			value = value.Elem()
+		default:
+			if kind, _ := fields.Container(value.Type()); kind == fields.Plain {
+				return value, Present
+			}
+			value = value.FieldByName("Value")
+		}
+	}
+}
+
+// reset makes a container undefined, or null if it is nullable or isNull is set, keeping its configuration:
+// the exported fields other than Value and IsPresent, such as New. Other values are set to their zero value.
+func reset(target reflect.Value, isNull bool) {
+	kind, _ := fields.Container(target.Type())
+	fresh := reflect.New(target.Type())
+	if kind != fields.Plain {
+		t := target.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if sf := t.Field(i); sf.IsExported() && sf.Name != "Value" && sf.Name != "IsPresent" {
+				fresh.Elem().Field(i).Set(target.Field(i))
+			}
+		}
+		if d, ok := fresh.Interface().(interface{ SetDefined(bool) }); ok {
+			d.SetDefined(isNull)
+		}
+	}
+	target.Set(fresh.Elem())
+}
+
+// decode replaces target with the value decoded from data. Containers are reset and decoded in place,
+// so that they keep their configuration; a null container stays defined. The containers of a struct
+// keep their configuration too, see blank.
+func decode(target reflect.Value, data []byte) error {
+	if kind, _ := fields.Container(target.Type()); kind != fields.Plain {
+		reset(target, true)
+		if n := target.FieldByName("New"); n.IsValid() && n.IsNil() {
+			// Allocate the value of an Object, or the items of an Array, in case it has no New function.
+			if out := n.Type().Out(0); out.Kind() == reflect.Pointer {
+				n.Set(reflect.MakeFunc(n.Type(), func([]reflect.Value) []reflect.Value {
+					return []reflect.Value{reflect.New(out.Elem())}
+				}))
+				defer n.SetZero()
+			}
+		}
+		if err := unmarshal(data, target.Addr().Interface()); err != nil {
+			return fmt.Errorf("%w: %w", ErrType, err)
+		}
+		return nil
+	}
+	fresh := blank(target)
+	if err := unmarshal(data, fresh.Interface()); err != nil {
+		return fmt.Errorf("%w: %w", ErrType, err)
+	}
+	target.Set(fresh.Elem())
+	return nil
+}
+
+// blank returns a pointer to a new value of the type of target, whose containers are undefined but keep
+// the configuration of those of target, through the fields of plain structs.
+func blank(target reflect.Value) reflect.Value {
+	fresh := reflect.New(target.Type())
+	if kind, _ := fields.Container(target.Type()); kind != fields.Plain || target.Kind() != reflect.Struct {
+		return fresh
+	}
+	for i := 0; i < target.NumField(); i++ {
+		sf := target.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if kind, _ := fields.Container(sf.Type); kind != fields.Plain {
+			fresh.Elem().Field(i).Set(target.Field(i))
+			reset(fresh.Elem().Field(i), false)
+		} else if sf.Type.Kind() == reflect.Struct {
+			fresh.Elem().Field(i).Set(blank(target.Field(i)).Elem())
+		}
+	}
+	return fresh
+}
+
+func unmarshal(data []byte, v any) error {
+	if u, ok := v.(easyjson.Unmarshaler); ok {
+		return easyjson.Unmarshal(data, u)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,175 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/typemap"
+)
+
+// Generator builds the schemas of Go types by reflection. Struct types are defined once,
+// and referenced with $ref wherever they are used, so recursive types are supported.
+type Generator struct {
+	refPrefix string
+	defs      map[string]*Schema
+	names     map[reflect.Type]string
+	types     typemap.Mapper
+}
+
+// NewGenerator returns a generator whose references are made of refPrefix followed by the name of the definition,
+// such as "#/$defs/" for a schema with a $defs keyword, which is the default if refPrefix is empty.
+func NewGenerator(refPrefix string) *Generator {
+	if refPrefix == "" {
+		refPrefix = "#/$defs/"
+	}
+	return &Generator{
+		refPrefix: refPrefix,
+		defs:      make(map[string]*Schema),
+		names:     make(map[reflect.Type]string),
+	}
+}
+
+// Registry is implemented by union.Registry, whatever its type argument.
+type Registry = typemap.Registry
+
+// Union adds the variants of a union registry, so that the optional.Union and nullable.Union containers
+// of the same type argument are described as one of the variants. Without it, they are described as any object.
+// It returns the generator so that calls can be chained.
+func (g *Generator) Union(registry Registry) *Generator {
+	if !g.types.AddUnion(registry) {
+		panic(fmt.Sprintf("jsonschema: %T is not a union registry", registry))
+	}
+	return g
+}
+
+// Schema returns the schema of the type of v, which is a reference for a struct type.
+func (g *Generator) Schema(v any) *Schema {
+	return g.typeSchema(reflect.TypeOf(v))
+}
+
+// Defs returns the definitions of the struct types that have been referenced, keyed by their name.
+func (g *Generator) Defs() map[string]*Schema {
+	return g.defs
+}
+
+// For returns a schema document describing the type of v, with the definitions of the struct types it uses.
+func For(v any) *Schema {
+	g := NewGenerator("")
+	s := g.Schema(v)
+	s.Schema = Draft
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s
+}
+
+func (g *Generator) typeSchema(t reflect.Type) *Schema {
+	shape := g.types.Of(t)
+	s := g.shapeSchema(shape)
+	if shape.Null {
+		return nullable(s)
+	}
+	return s
+}
+
+func (g *Generator) shapeSchema(shape typemap.Shape) *Schema {
+	switch shape.Kind {
+	case typemap.Boolean:
+		return &Schema{Type: Types{"boolean"}}
+	case typemap.Integer:
+		return &Schema{Type: Types{"integer"}}
+	case typemap.Number:
+		return &Schema{Type: Types{"number"}}
+	case typemap.String:
+		return &Schema{Type: Types{"string"}}
+	case typemap.DateTime:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case typemap.Base64:
+		return &Schema{Type: Types{"string"}, ContentEncoding: "base64"}
+	case typemap.IntegerString:
+		return &Schema{Type: Types{"string"}, Pattern: `^-?[0-9]+$`}
+	case typemap.Array:
+		return &Schema{Type: Types{"array"}, Items: g.typeSchema(shape.Elem)}
+	case typemap.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: g.typeSchema(shape.Elem)}
+	case typemap.Struct:
+		return g.ref(shape.Elem)
+	case typemap.Union:
+		return g.unionSchema(shape)
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *Generator) unionSchema(shape typemap.Shape) *Schema {
+	if shape.Variants == nil {
+		return &Schema{Type: Types{"object"}}
+	}
+	s := &Schema{}
+	for _, v := range shape.Variants {
+		s.OneOf = append(s.OneOf, &Schema{AllOf: []*Schema{
+			g.typeSchema(v.Type),
+			{
+				Properties: Properties{{Name: shape.Discriminator, Schema: &Schema{Const: v.Key}}},
+				Required:   []string{shape.Discriminator},
+			},
+		}})
+	}
+	return s
+}
+
+// ref returns a reference to the definition of the struct type t, defining it first if needed.
+func (g *Generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = typemap.Name(t, func(name string) bool { return g.defs[name] != nil })
+		g.names[t] = name
+		g.defs[name] = &Schema{}
+		*g.defs[name] = *g.define(t)
+	}
+	return &Schema{Ref: g.refPrefix + name}
+}
+
+func (g *Generator) define(t reflect.Type) *Schema {
+	if t == typemap.ProblemDetails {
+		return ProblemDetails()
+	}
+	s := &Schema{Type: Types{"object"}}
+	for _, f := range fields.Of(t) {
+		fs := g.typeSchema(f.Type)
+		applyOptions(fs, f.Options())
+		s.Properties = append(s.Properties, Property{Name: f.Name, Schema: fs})
+
+		_, required := f.Option("required")
+		if required || !typemap.Omissible(f) {
+			s.Required = append(s.Required, f.Name)
+		}
+	}
+	return s
+}
+
+// nullable returns a schema that also accepts null.
+func nullable(s *Schema) *Schema {
+	switch {
+	case len(s.Type) > 0:
+		if !s.Type.has("null") {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	case s.Ref == "" && len(s.OneOf) == 0:
+		// The empty schema already accepts null.
+		return s
+	default:
+		return &Schema{OneOf: []*Schema{s, {Type: Types{"null"}}}}
+	}
+}
+
+func (t Types) has(name string) bool {
+	for _, n := range t {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
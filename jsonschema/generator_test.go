@@ -0,0 +1,35 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/binadel/payloads/jsonschema"
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/optional"
+)
+
+func TestRequired(t *testing.T) {
+	type payload struct {
+		ID        int             `json:"id"`
+		Note      string          `json:"note,omitempty"`
+		Name      optional.String `json:"name"`
+		Email     optional.String `json:"email" payload:"required"`
+		Bio       nullable.String `json:"bio"`
+		Nickname  nullable.String `json:"nickname,omitempty"`
+		Signature nullable.String `json:"signature,omitempty" payload:"required"`
+	}
+	g := jsonschema.NewGenerator("")
+	g.Schema(payload{})
+	var schema *jsonschema.Schema
+	for _, s := range g.Defs() {
+		schema = s
+	}
+	if schema == nil {
+		t.Fatal("the payload was not defined")
+	}
+	want := []string{"id", "email", "bio", "signature"}
+	if !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("Required = %v, want %v", schema.Required, want)
+	}
+}
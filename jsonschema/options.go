@@ -0,0 +1,59 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/number"
+)
+
+// applyOptions adds to the schema of a field the keywords matching the options of its payload tag,
+// which are the rules of the validate package, the default option and the access modes of the access package.
+func applyOptions(s *Schema, options []fields.Option) {
+	for _, o := range options {
+		switch o.Name {
+		case "min":
+			if number.Valid(o.Arg) {
+				s.Minimum = json.Number(o.Arg)
+			}
+		case "max":
+			if number.Valid(o.Arg) {
+				s.Maximum = json.Number(o.Arg)
+			}
+		case "minLength":
+			s.MinLength = atoi(o.Arg)
+		case "maxLength":
+			s.MaxLength = atoi(o.Arg)
+		case "pattern":
+			s.Pattern = o.Arg
+		case "format":
+			s.Format = o.Arg
+		case "minItems":
+			s.MinItems = atoi(o.Arg)
+		case "maxItems":
+			s.MaxItems = atoi(o.Arg)
+		case "uniqueItems":
+			s.UniqueItems = true
+		case "readOnly":
+			s.ReadOnly = true
+		case "writeOnly":
+			s.WriteOnly = true
+		case "default":
+			if json.Valid([]byte(o.Arg)) {
+				s.Default = json.RawMessage(o.Arg)
+			} else {
+				s.Default, _ = json.Marshal(o.Arg)
+			}
+		}
+	}
+}
+
+// atoi returns a pointer to the integer s, or nil if it is not an integer.
+func atoi(s string) *int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
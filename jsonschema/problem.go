@@ -0,0 +1,58 @@
+package jsonschema
+
+// ProblemDetails returns the schema of problem.Details, as defined by RFC 9457,
+// including the errors extension member listing the individual problems of a validation problem.
+func ProblemDetails() *Schema {
+	return &Schema{
+		Title:       "ProblemDetails",
+		Description: "A problem details object, as defined by RFC 9457.",
+		Type:        Types{"object"},
+		Properties: Properties{
+			{Name: "type", Schema: &Schema{
+				Type:        Types{"string"},
+				Format:      "uri-reference",
+				Description: "A URI reference that identifies the problem type.",
+				Default:     []byte(`"about:blank"`),
+			}},
+			{Name: "title", Schema: &Schema{
+				Type:        Types{"string"},
+				Description: "A short, human-readable summary of the problem type.",
+			}},
+			{Name: "status", Schema: &Schema{
+				Type:        Types{"integer"},
+				Description: "The HTTP status code generated by the origin server for this occurrence of the problem.",
+				Minimum:     "100",
+				Maximum:     "599",
+			}},
+			{Name: "detail", Schema: &Schema{
+				Type:        Types{"string"},
+				Description: "A human-readable explanation specific to this occurrence of the problem.",
+			}},
+			{Name: "instance", Schema: &Schema{
+				Type:        Types{"string"},
+				Format:      "uri-reference",
+				Description: "A URI reference that identifies the specific occurrence of the problem.",
+			}},
+			{Name: "errors", Schema: &Schema{
+				Type:        Types{"array"},
+				Description: "The individual problems found in the request content.",
+				Items: &Schema{
+					Type: Types{"object"},
+					Properties: Properties{
+						{Name: "detail", Schema: &Schema{
+							Type:        Types{"string"},
+							Description: "A human-readable explanation of this problem.",
+						}},
+						{Name: "pointer", Schema: &Schema{
+							Type:        Types{"string"},
+							Format:      "json-pointer",
+							Description: "A JSON Pointer to the offending value in the request content.",
+						}},
+					},
+					Required: []string{"detail", "pointer"},
+				},
+			}},
+		},
+		Required: []string{"type", "title", "status", "detail", "instance"},
+	}
+}
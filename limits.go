@@ -0,0 +1,141 @@
+package payloads
+
+import (
+	"fmt"
+
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// Limits bounds the size of untrusted payloads, a zero field means that there is no limit.
+//
+// A Decoder checks the whole payload against its limits before decoding it. The containers and problem.Details
+// enforce the limits of the Decoder decoding them, or DefaultLimits otherwise, such as with easyjson.Unmarshal,
+// json.Unmarshal or the binding of a web framework. When one of them is the whole payload, it checks it like
+// a Decoder. Nested in another struct, the arrays enforce MaxItems, the strings MaxStringBytes and the Bytes
+// containers MaxBytesLength, while MaxDepth and MaxBytes are left to the decoder of the root struct.
+type Limits struct {
+	// MaxBytes is the maximum size of the whole payload.
+	MaxBytes int
+
+	// MaxDepth is the maximum nesting of arrays and objects, a top-level array or object has depth 1.
+	MaxDepth int
+
+	// MaxItems is the maximum number of items of an array or members of an object.
+	MaxItems int
+
+	// MaxStringBytes is the maximum length of a decoded string, including object keys.
+	MaxStringBytes int
+
+	// MaxBytesLength is the maximum length of the data decoded by the Bytes containers.
+	// Unlike the other limits, it is not checked by Check but while decoding.
+	MaxBytesLength int
+}
+
+// DefaultLimits are the limits enforced by the containers when they are not decoded by a Decoder, see Limits.
+// They are zero by default, which enforces nothing, and are meant to be set once at startup, before decoding.
+var DefaultLimits Limits
+
+// LimitError is reported when a payload exceeds one of its Limits.
+type LimitError struct {
+	// Limit is the name of the exceeded limit, such as "MaxItems" for Limits.MaxItems.
+	Limit string
+
+	// Max is the value of the exceeded limit.
+	Max int
+
+	// Offset is the position in the input where the limit was exceeded, see Locate.
+	Offset int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("payload exceeds the %s limit of %d near offset %d", e.Limit, e.Max, e.Offset)
+}
+
+// limitFrame is an array or object being scanned by Check.
+type limitFrame struct {
+	isObject bool
+	items    int
+}
+
+// Check scans data and reports a LimitError if it exceeds the limits.
+// It does not allocate in proportion to the input, and leaves syntax errors to the decoder.
+func (lim Limits) Check(data []byte) error {
+	if lim.MaxBytes > 0 && len(data) > lim.MaxBytes {
+		return &LimitError{Limit: "MaxBytes", Max: lim.MaxBytes, Offset: lim.MaxBytes}
+	}
+	if lim.MaxDepth <= 0 && lim.MaxItems <= 0 && lim.MaxStringBytes <= 0 {
+		return nil
+	}
+
+	l := jlexer.Lexer{Data: data}
+	var stack []limitFrame
+	for {
+		opened := true
+		switch {
+		case l.IsDelim('{'):
+			l.Delim('{')
+			stack = append(stack, limitFrame{isObject: true})
+		case l.IsDelim('['):
+			l.Delim('[')
+			stack = append(stack, limitFrame{})
+		case l.CurrentToken() == jlexer.TokenString:
+			opened = false
+			if err := lim.checkString(&l); err != nil {
+				return err
+			}
+		default:
+			opened = false
+			l.Skip()
+		}
+		if opened && lim.MaxDepth > 0 && len(stack) > lim.MaxDepth {
+			return &LimitError{Limit: "MaxDepth", Max: lim.MaxDepth, Offset: l.GetPos() - 1}
+		}
+		if !opened {
+			l.WantComma()
+		}
+
+		for len(stack) > 0 {
+			if top := stack[len(stack)-1]; top.isObject && l.IsDelim('}') {
+				l.Delim('}')
+			} else if !top.isObject && l.IsDelim(']') {
+				l.Delim(']')
+			} else {
+				break
+			}
+			stack = stack[:len(stack)-1]
+			l.WantComma()
+		}
+		if !l.Ok() || len(stack) == 0 {
+			return nil
+		}
+
+		top := &stack[len(stack)-1]
+		top.items++
+		if lim.MaxItems > 0 && top.items > lim.MaxItems {
+			// The offset is that of the first item, or member name, over the limit.
+			raw := l.Raw()
+			return &LimitError{Limit: "MaxItems", Max: lim.MaxItems, Offset: l.GetPos() - len(raw)}
+		}
+		if top.isObject {
+			if err := lim.checkString(&l); err != nil {
+				return err
+			}
+			l.WantColon()
+		}
+	}
+}
+
+// checkString reads the next string and reports a LimitError if it is too long.
+func (lim Limits) checkString(l *jlexer.Lexer) error {
+	raw := l.Raw()
+	offset := l.GetPos() - len(raw)
+	if lim.MaxStringBytes <= 0 || len(raw)-2 <= lim.MaxStringBytes {
+		return nil
+	}
+	// The raw string may be longer than the decoded one because of escape sequences.
+	s := jlexer.Lexer{Data: raw}
+	if len(s.UnsafeBytes()) > lim.MaxStringBytes {
+		return &LimitError{Limit: "MaxStringBytes", Max: lim.MaxStringBytes, Offset: offset}
+	}
+	return nil
+}
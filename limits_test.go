@@ -0,0 +1,108 @@
+package payloads_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/optional"
+	"github.com/binadel/payloads/problem"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// setDefaultLimits sets payloads.DefaultLimits for the duration of a test.
+func setDefaultLimits(t *testing.T, lim payloads.Limits) {
+	saved := payloads.DefaultLimits
+	payloads.DefaultLimits = lim
+	t.Cleanup(func() { payloads.DefaultLimits = saved })
+}
+
+// wantLimit checks that err is a LimitError for the given limit, located at pointer in data.
+func wantLimit(t *testing.T, data string, err error, limit, pointer string) {
+	t.Helper()
+	var limitErr *payloads.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != limit {
+		t.Fatalf("error = %v, want a %s LimitError", err, limit)
+	}
+	if got := payloads.Locate([]byte(data), limitErr.Offset); got != pointer {
+		t.Errorf("error located at %q, want %q", got, pointer)
+	}
+}
+
+// tagged is decoded by hand like the code generated by easyjson, so its fields are not at the start of the input.
+type tagged struct {
+	Tags optional.StringArray
+}
+
+func (v *tagged) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if key == "tags" {
+			v.Tags.UnmarshalEasyJSON(l)
+		} else {
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+func TestDefaultLimits(t *testing.T) {
+	setDefaultLimits(t, payloads.Limits{MaxItems: 2, MaxDepth: 3, MaxStringBytes: 8})
+
+	t.Run("WholeArray", func(t *testing.T) {
+		data := `[1,2,3]`
+		var v optional.IntArray
+		wantLimit(t, data, easyjson.Unmarshal([]byte(data), &v), "MaxItems", "/2")
+	})
+	t.Run("WholeArrayDepth", func(t *testing.T) {
+		data := `[{"a":[[1]]}]`
+		var v optional.AnyArray[map[string]any]
+		wantLimit(t, data, json.Unmarshal([]byte(data), &v), "MaxDepth", "/0/a/0")
+	})
+	t.Run("NestedArray", func(t *testing.T) {
+		data := `{"tags":["a","b","c"]}`
+		var v tagged
+		wantLimit(t, data, easyjson.Unmarshal([]byte(data), &v), "MaxItems", "/tags/2")
+	})
+	t.Run("NestedString", func(t *testing.T) {
+		data := `{"tags":["a","bcdefghij"]}`
+		var v tagged
+		wantLimit(t, data, easyjson.Unmarshal([]byte(data), &v), "MaxStringBytes", "/tags/1")
+	})
+	t.Run("ProblemDetails", func(t *testing.T) {
+		data := `{"errors":[{"detail":"a"},{"detail":"b"},{"detail":"c"}]}`
+		var v problem.Details
+		wantLimit(t, data, easyjson.Unmarshal([]byte(data), &v), "MaxItems", "/errors/2")
+	})
+	t.Run("WithinLimits", func(t *testing.T) {
+		var v tagged
+		if err := easyjson.Unmarshal([]byte(`{"tags":["a","b"]}`), &v); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestDecoderLimits(t *testing.T) {
+	// The limits of the Decoder apply instead of the default ones.
+	setDefaultLimits(t, payloads.Limits{MaxItems: 1})
+	d := payloads.Decoder{Limits: payloads.Limits{MaxItems: 2}}
+
+	data := `{"errors":[{"detail":"a"},{"detail":"b"}]}`
+	var v problem.Details
+	if err := d.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	data = `{"errors":[{"detail":"a"},{"detail":"b"},{"detail":"c"}]}`
+	err := d.Unmarshal([]byte(data), &v)
+	wantLimit(t, data, err, "MaxItems", "/errors/2")
+	details := problem.FromDecodeError([]byte(data), err)
+	if len(details.Errors) != 1 || details.Errors[0].Pointer != "/errors/2" {
+		t.Errorf("FromDecodeError().Errors = %+v, want one error at /errors/2", details.Errors)
+	}
+}
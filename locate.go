@@ -0,0 +1,83 @@
+package payloads
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/binadel/payloads/internal/jsonpointer"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// locateFrame is a container being scanned by Locate.
+type locateFrame struct {
+	isArray bool
+	index   int
+	key     string
+}
+
+// Locate returns the JSON Pointer of the value that starts at the given offset of data,
+// such as "/items/3/price". It is meant to turn the offset of a decoding error into a location
+// that can be reported to the client. Offsets that fall inside a value resolve to the innermost
+// container whose member was being scanned, and an offset on a member name resolves to that member.
+func Locate(data []byte, offset int) string {
+	var stack []locateFrame
+	expectKey := false
+
+	for i := 0; i < len(data) && i < offset; i++ {
+		switch c := data[i]; c {
+		case '{':
+			stack = append(stack, locateFrame{})
+			expectKey = true
+		case '[':
+			stack = append(stack, locateFrame{isArray: true})
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			expectKey = false
+		case ',':
+			if len(stack) > 0 {
+				top := &stack[len(stack)-1]
+				if top.isArray {
+					top.index++
+				} else {
+					expectKey = true
+				}
+			}
+		case '"':
+			start := i
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if expectKey && len(stack) > 0 && i < len(data) {
+				l := jlexer.Lexer{Data: data[start : i+1]}
+				stack[len(stack)-1].key = l.String()
+				expectKey = false
+			}
+		}
+	}
+
+	if expectKey && len(stack) > 0 {
+		// The member of the innermost object is not known yet: the offset is either on its name,
+		// which is then resolved, or before it, where the object itself is located.
+		if offset < len(data) && data[offset] == '"' {
+			l := jlexer.Lexer{Data: data[offset:]}
+			stack[len(stack)-1].key = l.String()
+		} else {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	var b strings.Builder
+	for _, f := range stack {
+		b.WriteByte('/')
+		if f.isArray {
+			b.WriteString(strconv.Itoa(f.index))
+		} else {
+			b.WriteString(jsonpointer.Escape(f.key))
+		}
+	}
+	return b.String()
+}
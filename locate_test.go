@@ -0,0 +1,32 @@
+package payloads_test
+
+import (
+	"testing"
+
+	"github.com/binadel/payloads"
+)
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		data   string
+		offset int
+		want   string
+	}{
+		{`{"a":{"b":1}}`, 10, "/a/b"},
+		{`{"a":[1,2,3]}`, 10, "/a/2"},
+		{`{"a~b":{"c/d":true}}`, 13, "/a~0b/c~1d"},
+		{`{"a":1}`, 0, ""},
+		// An offset on a member name resolves to the member.
+		{`{"a":{"b":1}}`, 6, "/a/b"},
+		{`[{"a":1},{"b":2}]`, 10, "/1/b"},
+		{`{"a":1,"b!":2}`, 7, "/b!"},
+		// An offset before the first member name resolves to the object.
+		{`{"a":{ "b":1}}`, 6, "/a"},
+		{`[{"a":1},{"b":2}]`, 9, "/1"},
+	}
+	for _, tt := range tests {
+		if got := payloads.Locate([]byte(tt.data), tt.offset); got != tt.want {
+			t.Errorf("Locate(%s, %d) = %q, want %q", tt.data, tt.offset, got, tt.want)
+		}
+	}
+}
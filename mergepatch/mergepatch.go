@@ -0,0 +1,169 @@
+// Package mergepatch computes JSON Merge Patches, as defined by RFC 7396, between payloads made of structs
+// with optional fields. A merge patch has the type of the payloads it applies to, with only the changed fields defined.
+package mergepatch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/fields"
+)
+
+// MIMEMergePatch is the media type of a JSON Merge Patch document.
+const MIMEMergePatch = "application/merge-patch+json"
+
+// Diff returns the merge patch that turns the payload from into the payload to, which are structs or pointers
+// to structs. A field that is undefined in either payload is not part of the comparison. A changed field is defined
+// in the patch with its value in to, which is null if it was removed. Nested structs, such as the value of an
+// optional.Object, are compared field by field, and the other fields are compared by their JSON encoding.
+// Secrets are compared and copied with their value, and can be encoded with a payloads.Encoder that exposes them.
+//
+// The fields of the patch that did not change are left undefined, so the fields of the payloads, and of the nested
+// structs compared field by field, must be optional or nullable. A nullable field cannot be left out of a patch:
+// null is the removal of its member, so it is in the patch with its value in to even if it did not change, which
+// is harmless. Diff fails for plain fields, and for nullable fields with the omitempty option that are removed,
+// since their null would not be encoded.
+func Diff[T any](from, to T) (T, error) {
+	var patch T
+	a, okA := diff.Struct(reflect.ValueOf(from))
+	b, okB := diff.Struct(reflect.ValueOf(to))
+	if !okA || !okB || a.Type() != b.Type() {
+		return patch, fmt.Errorf("mergepatch: cannot diff %T and %T", from, to)
+	}
+	if err := check(a.Type(), make(map[reflect.Type]bool)); err != nil {
+		return patch, err
+	}
+	result := reflect.ValueOf(&patch).Elem()
+	if result.Kind() == reflect.Pointer {
+		result.Set(reflect.New(a.Type()))
+		result = result.Elem()
+	}
+	if _, err := diffStruct(a, b, result); err != nil {
+		var zero T
+		return zero, err
+	}
+	return patch, nil
+}
+
+// check returns an error if the struct type t, or a struct type nested in its containers, has a plain field.
+func check(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	for _, f := range fields.Of(t) {
+		kind, value := fields.Container(f.Type)
+		if kind == fields.Plain {
+			return fmt.Errorf("mergepatch: field %s of %s is not optional or nullable, so it cannot be left out of a patch", f.Name, t)
+		}
+		for value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct || diff.Custom(value) {
+			continue
+		}
+		if kind, _ := fields.Container(value); kind != fields.Plain {
+			continue
+		}
+		if err := check(value, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffStruct defines in result the fields that differ between a and b, and the nullable fields of b,
+// and returns whether any field differs.
+func diffStruct(a, b, result reflect.Value) (bool, error) {
+	changed := false
+	for _, f := range fields.Of(a.Type()) {
+		fa, errA := a.FieldByIndexErr(f.Index)
+		fb, errB := b.FieldByIndexErr(f.Index)
+		fr, errR := result.FieldByIndexErr(f.Index)
+		if errA != nil || errB != nil || errR != nil {
+			// The field is promoted from a nil embedded pointer.
+			continue
+		}
+		sa, sb := fields.StateOf(fa), fields.StateOf(fb)
+		if sa == fields.Undefined || sb == fields.Undefined {
+			continue
+		}
+		kind, _ := fields.Container(f.Type)
+		if kind == fields.Nullable && sb == fields.Null {
+			// The field is left null in the patch, which removes the member.
+			if sa != fields.Null {
+				if f.OmitEmpty {
+					return false, fmt.Errorf("mergepatch: field %s of %s is removed, but its null is omitted from the patch", f.Name, a.Type())
+				}
+				changed = true
+			}
+			continue
+		}
+		if sa == fields.Present && sb == fields.Present {
+			na, okA := diff.Struct(fa)
+			nb, okB := diff.Struct(fb)
+			if okA && okB && na.Type() == nb.Type() {
+				if nested, ok := slot(fr, fb); ok {
+					nestedChanged, err := diffStruct(na, nb, nested)
+					if err != nil {
+						return false, err
+					}
+					if nestedChanged {
+						define(fr)
+						changed = true
+					} else if kind != fields.Nullable {
+						fr.SetZero()
+					}
+					continue
+				}
+			}
+		}
+		equal := diff.Equal(fa, fb)
+		if equal && kind != fields.Nullable {
+			continue
+		}
+		if value := reflect.ValueOf(deepcopy.Copy(fb.Interface())); value.IsValid() {
+			fr.Set(value)
+		}
+		define(fr)
+		changed = changed || !equal
+	}
+	return changed, nil
+}
+
+// slot prepares the field r of the patch to hold a nested struct, keeping the configuration of the container
+// of b, such as the New function of an optional.Object, and returns the struct. It returns false if the struct
+// is held through an interface, which cannot be prepared.
+func slot(r, b reflect.Value) (reflect.Value, bool) {
+	for {
+		switch r.Kind() {
+		case reflect.Pointer:
+			r.Set(reflect.New(r.Type().Elem()))
+			r, b = r.Elem(), b.Elem()
+		case reflect.Struct:
+			if kind, _ := fields.Container(r.Type()); kind == fields.Plain {
+				return r, true
+			}
+			for i := 0; i < r.NumField(); i++ {
+				if sf := r.Type().Field(i); sf.IsExported() && sf.Name != "Value" && sf.Name != "IsPresent" {
+					r.Field(i).Set(b.Field(i))
+				}
+			}
+			if present := r.FieldByName("IsPresent"); present.IsValid() {
+				present.SetBool(true)
+			}
+			r, b = r.FieldByName("Value"), b.FieldByName("Value")
+		default:
+			return reflect.Value{}, false
+		}
+	}
+}
+
+// define marks the field of the patch as defined, if it is an optional container.
+func define(r reflect.Value) {
+	if d, ok := r.Addr().Interface().(interface{ SetDefined(bool) }); ok {
+		d.SetDefined(true)
+	}
+}
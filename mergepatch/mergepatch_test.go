@@ -0,0 +1,104 @@
+package mergepatch_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/mergepatch"
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/optional"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+type profile struct {
+	Name     optional.String `json:"name,omitempty"`
+	Password optional.Secret `json:"password,omitempty"`
+	Bio      nullable.String `json:"bio"`
+}
+
+// MarshalEasyJSON encodes a profile like the code generated by easyjson, which leaves out the members
+// with the omitempty option that are not defined.
+func (v profile) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawByte('{')
+	comma := false
+	member := func(name string) {
+		if comma {
+			w.RawByte(',')
+		}
+		comma = true
+		w.String(name)
+		w.RawByte(':')
+	}
+	if v.Name.IsDefined() {
+		member("name")
+		v.Name.MarshalEasyJSON(w)
+	}
+	if v.Password.IsDefined() {
+		member("password")
+		v.Password.MarshalEasyJSON(w)
+	}
+	member("bio")
+	v.Bio.MarshalEasyJSON(w)
+	w.RawByte('}')
+}
+
+func TestDiff(t *testing.T) {
+	from := profile{
+		Name:     optional.Of[optional.String]("ada"),
+		Password: optional.Of[optional.Secret]("hunter2"),
+		Bio:      nullable.Of[nullable.String]("analyst"),
+	}
+	tests := []struct {
+		name     string
+		to       profile
+		want     string
+		password string
+	}{
+		{"Unchanged", from, `{"bio":"analyst"}`, ""},
+		{"Password", profile{Name: from.Name, Password: optional.Of[optional.Secret]("hunter3"), Bio: from.Bio},
+			`{"password":"hunter3","bio":"analyst"}`, "hunter3"},
+		{"BioRemoved", profile{Name: from.Name, Password: from.Password},
+			`{"bio":null}`, ""},
+		{"BioChanged", profile{Name: optional.Null[optional.String](), Bio: nullable.Of[nullable.String]("mathematician")},
+			`{"name":null,"bio":"mathematician"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := mergepatch.Diff(from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := payloads.Encoder{ExposeSecrets: true}.Marshal(patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Diff() = %s, want %s", data, tt.want)
+			}
+			if got, _ := patch.Password.Lookup(); got != tt.password {
+				t.Errorf("Diff().Password = %q, want %q", got, tt.password)
+			}
+		})
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	type plain struct {
+		Name string `json:"name"`
+	}
+	if _, err := mergepatch.Diff(plain{}, plain{}); err == nil || !strings.Contains(err.Error(), "not optional or nullable") {
+		t.Errorf("Diff() error = %v, want a plain field error", err)
+	}
+
+	type omitted struct {
+		Bio nullable.String `json:"bio,omitempty"`
+	}
+	from := omitted{Bio: nullable.Of[nullable.String]("analyst")}
+	if _, err := mergepatch.Diff(from, omitted{}); err == nil || !strings.Contains(err.Error(), "null is omitted") {
+		t.Errorf("Diff() error = %v, want a removal error", err)
+	}
+	if _, err := mergepatch.Diff(from, from); err != nil {
+		t.Errorf("Diff() error = %v for an unchanged field", err)
+	}
+}
@@ -0,0 +1,21 @@
+package payloads
+
+import "errors"
+
+// ErrNonFinite is reported when marshaling NaN or an infinite value with the NonFiniteError mode.
+var ErrNonFinite = errors.New("NaN and infinite values are not supported by JSON")
+
+// NonFiniteMode determines how the float containers encode NaN and infinite values,
+// which have no JSON representation. It is set per Encoder.
+type NonFiniteMode int
+
+const (
+	// NonFiniteError fails the marshaling with an error wrapping ErrNonFinite.
+	NonFiniteError NonFiniteMode = iota
+
+	// NonFiniteNull writes null in place of the value.
+	NonFiniteNull
+
+	// NonFiniteString writes the value as one of the strings "NaN", "Infinity" and "-Infinity".
+	NonFiniteString
+)
@@ -3,6 +3,11 @@
 package nullable
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -33,6 +38,16 @@ func (v *Bool) Set(value bool) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Bool) Lookup() (bool, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are null, or have the same value.
+func (v Bool) Equal(other Bool) bool {
+	return v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Bool) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
@@ -48,7 +63,7 @@ func (v *Bool) UnmarshalEasyJSON(l *jlexer.Lexer) {
 		l.Skip()
 		*v = Bool{}
 	} else {
-		v.Value = l.Bool()
+		v.Value = decode.Bool(l)
 		v.IsPresent = true
 	}
 }
@@ -66,3 +81,28 @@ func (v *Bool) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "null" or the value formatted with %v.
+func (v Bool) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as nullable.Of[nullable.Bool](value).
+func (v Bool) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "null" or the value formatted with the given verb.
+func (v Bool) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs show the value as null or as the value.
+func (v Bool) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Bool) state() format.State {
+	return format.Of(true, v.IsPresent)
+}
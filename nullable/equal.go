@@ -0,0 +1,19 @@
+package nullable
+
+import (
+	"bytes"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// equalEasyJSON determines whether both values have the same JSON form.
+func equalEasyJSON(a, b easyjson.Marshaler) bool {
+	wa, wb := jwriter.Writer{}, jwriter.Writer{}
+	a.MarshalEasyJSON(&wa)
+	b.MarshalEasyJSON(&wb)
+	if wa.Error != nil || wb.Error != nil {
+		return false
+	}
+	return bytes.Equal(wa.Buffer.BuildBytes(), wb.Buffer.BuildBytes())
+}
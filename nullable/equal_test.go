@@ -0,0 +1,154 @@
+package nullable_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/union"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// point is a minimal easyjson struct for the Union container.
+type point struct {
+	X, Y int
+}
+
+func (p *point) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"x":` + strconv.Itoa(p.X) + `,"y":` + strconv.Itoa(p.Y) + `}`)
+}
+
+func (p *point) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		switch key {
+		case "x":
+			p.X = l.Int()
+		case "y":
+			p.Y = l.Int()
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+// origin has the same fields as point, but is a different variant of the union.
+type origin struct {
+	point
+}
+
+var shapes = union.NewRegistry[easyjson.MarshalerUnmarshaler]("kind").
+	Register("point", func() easyjson.MarshalerUnmarshaler { return new(point) }).
+	Register("origin", func() easyjson.MarshalerUnmarshaler { return new(origin) })
+
+func unionOf(v easyjson.MarshalerUnmarshaler) nullable.Union[easyjson.MarshalerUnmarshaler] {
+	u := nullable.Union[easyjson.MarshalerUnmarshaler]{Registry: shapes}
+	u.Set(v)
+	return u
+}
+
+// roundTrip checks that value is equal to itself, to its decoded encoding, and to its clone if it has one.
+// The empty value is decoded into, so that it can hold the registry that decoding needs.
+func roundTrip[T interface {
+	Equal(T) bool
+	easyjson.Marshaler
+}, P interface {
+	*T
+	easyjson.Unmarshaler
+}](value, empty T, clone func(T) T) func(*testing.T) {
+	return func(t *testing.T) {
+		if !value.Equal(value) {
+			t.Fatalf("%v is not equal to itself", value)
+		}
+
+		data, err := payloads.Encoder{NonFinite: payloads.NonFiniteString}.Marshal(value)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		decoded := empty
+		if err := (payloads.Decoder{AcceptNonFinite: true}).Unmarshal(data, P(&decoded)); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if !value.Equal(decoded) || !decoded.Equal(value) {
+			t.Errorf("%v is not equal to %v decoded from %s", value, decoded, data)
+		}
+
+		if clone == nil {
+			return
+		}
+		c := clone(value)
+		if !value.Equal(c) || !c.Equal(value) {
+			t.Errorf("%v is not equal to its clone %v", value, c)
+		}
+		cloned, err := payloads.Encoder{NonFinite: payloads.NonFiniteString}.Marshal(c)
+		if err != nil {
+			t.Fatalf("Marshal clone: %v", err)
+		}
+		if !bytes.Equal(data, cloned) {
+			t.Errorf("clone is encoded as %s, want %s", cloned, data)
+		}
+	}
+}
+
+func TestEqualRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(*testing.T)
+	}{
+		{"Int", roundTrip(nullable.Of[nullable.Int](42), nullable.Int{}, nil)},
+		{"IntNull", roundTrip(nullable.Null[nullable.Int](), nullable.Int{}, nil)},
+		{"UInt64String", roundTrip(nullable.Of[nullable.UInt64String, uint64](1<<63), nullable.UInt64String{}, nil)},
+		{"String", roundTrip(nullable.Of[nullable.String]("café"), nullable.String{}, nil)},
+		{"Float64", roundTrip(nullable.Of[nullable.Float64](0.1), nullable.Float64{}, nil)},
+		{"Float64NaN", roundTrip(nullable.Of[nullable.Float64](math.NaN()), nullable.Float64{}, nil)},
+		{"Float32Inf", roundTrip(nullable.Of[nullable.Float32, float32](float32(math.Inf(1))), nullable.Float32{}, nil)},
+		{"Number", roundTrip(nullable.Of[nullable.Number, json.Number]("-0.0"), nullable.Number{}, nil)},
+		{"Bytes", roundTrip(nullable.Of[nullable.Bytes]([]byte("data")), nullable.Bytes{}, nullable.Bytes.Clone)},
+		{"BytesNull", roundTrip(nullable.Null[nullable.Bytes](), nullable.Bytes{}, nullable.Bytes.Clone)},
+		{"Raw", roundTrip(nullable.Of[nullable.Raw, json.RawMessage]([]byte(`[{"a":1}]`)), nullable.Raw{}, nullable.Raw.Clone)},
+		{"Union", roundTrip(
+			unionOf(&origin{point{1, 2}}),
+			nullable.Union[easyjson.MarshalerUnmarshaler]{Registry: shapes},
+			nullable.Union[easyjson.MarshalerUnmarshaler].Clone)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestEqualDiffers(t *testing.T) {
+	tests := []struct {
+		name  string
+		equal bool
+	}{
+		{"NullZero", nullable.Null[nullable.Int]().Equal(nullable.Of[nullable.Int](0))},
+		{"Value", nullable.Of[nullable.String]("a").Equal(nullable.Of[nullable.String]("b"))},
+		{"NaNZero", nullable.Of[nullable.Float64](math.NaN()).Equal(nullable.Of[nullable.Float64, float64](0))},
+		{"BytesNullEmpty", nullable.Null[nullable.Bytes]().Equal(nullable.Of[nullable.Bytes]([]byte{}))},
+		{"UnionVariant", unionOf(&point{}).Equal(unionOf(&origin{}))},
+	}
+	for _, tt := range tests {
+		if tt.equal {
+			t.Errorf("%s: values are equal", tt.name)
+		}
+	}
+}
+
+func TestUnionEqualWithoutRegistry(t *testing.T) {
+	var a, b nullable.Union[easyjson.MarshalerUnmarshaler]
+	a.Set(&point{1, 2})
+	b.Set(&point{1, 2})
+	if !a.Equal(b) {
+		t.Error("unions holding the same value are not equal")
+	}
+}
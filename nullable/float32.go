@@ -3,6 +3,12 @@
 package nullable
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/encode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -33,10 +39,20 @@ func (v *Float32) Set(value float32) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Float32) Lookup() (float32, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are null, or have the same value.
+func (v Float32) Equal(other Float32) bool {
+	return v.IsPresent == other.IsPresent && (!v.IsPresent || encode.Same(v.Value, other.Value))
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Float32) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
-		w.Float32(v.Value)
+		encode.Float32(w, v.Value)
 	} else {
 		w.RawString("null")
 	}
@@ -48,7 +64,7 @@ func (v *Float32) UnmarshalEasyJSON(l *jlexer.Lexer) {
 		l.Skip()
 		*v = Float32{}
 	} else {
-		v.Value = l.Float32()
+		v.Value = decode.Float32(l)
 		v.IsPresent = true
 	}
 }
@@ -66,3 +82,28 @@ func (v *Float32) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "null" or the value formatted with %v.
+func (v Float32) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as nullable.Of[nullable.Float32](value).
+func (v Float32) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "null" or the value formatted with the given verb.
+func (v Float32) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs show the value as null or as the value.
+func (v Float32) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Float32) state() format.State {
+	return format.Of(true, v.IsPresent)
+}
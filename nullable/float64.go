@@ -3,6 +3,12 @@
 package nullable
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/encode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -33,10 +39,20 @@ func (v *Float64) Set(value float64) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Float64) Lookup() (float64, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are null, or have the same value.
+func (v Float64) Equal(other Float64) bool {
+	return v.IsPresent == other.IsPresent && (!v.IsPresent || encode.Same(v.Value, other.Value))
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Float64) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
-		w.Float64(v.Value)
+		encode.Float64(w, v.Value)
 	} else {
 		w.RawString("null")
 	}
@@ -48,7 +64,7 @@ func (v *Float64) UnmarshalEasyJSON(l *jlexer.Lexer) {
 		l.Skip()
 		*v = Float64{}
 	} else {
-		v.Value = l.Float64()
+		v.Value = decode.Float64(l)
 		v.IsPresent = true
 	}
 }
@@ -66,3 +82,28 @@ func (v *Float64) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "null" or the value formatted with %v.
+func (v Float64) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as nullable.Of[nullable.Float64](value).
+func (v Float64) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "null" or the value formatted with the given verb.
+func (v Float64) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs show the value as null or as the value.
+func (v Float64) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Float64) state() format.State {
+	return format.Of(true, v.IsPresent)
+}
@@ -3,6 +3,11 @@
 package nullable
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -33,6 +38,16 @@ func (v *Int16) Set(value int16) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Int16) Lookup() (int16, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are null, or have the same value.
+func (v Int16) Equal(other Int16) bool {
+	return v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Int16) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
@@ -48,7 +63,7 @@ func (v *Int16) UnmarshalEasyJSON(l *jlexer.Lexer) {
 		l.Skip()
 		*v = Int16{}
 	} else {
-		v.Value = l.Int16()
+		v.Value = decode.Int16(l)
 		v.IsPresent = true
 	}
 }
@@ -66,3 +81,28 @@ func (v *Int16) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "null" or the value formatted with %v.
+func (v Int16) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as nullable.Of[nullable.Int16](value).
+func (v Int16) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "null" or the value formatted with the given verb.
+func (v Int16) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs show the value as null or as the value.
+func (v Int16) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Int16) state() format.State {
+	return format.Of(true, v.IsPresent)
+}
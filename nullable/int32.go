@@ -3,6 +3,11 @@
 package nullable
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -33,6 +38,16 @@ func (v *Int32) Set(value int32) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Int32) Lookup() (int32, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are null, or have the same value.
+func (v Int32) Equal(other Int32) bool {
+	return v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Int32) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
@@ -48,7 +63,7 @@ func (v *Int32) UnmarshalEasyJSON(l *jlexer.Lexer) {
 		l.Skip()
 		*v = Int32{}
 	} else {
-		v.Value = l.Int32()
+		v.Value = decode.Int32(l)
 		v.IsPresent = true
 	}
 }
@@ -66,3 +81,28 @@ func (v *Int32) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "null" or the value formatted with %v.
+func (v Int32) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as nullable.Of[nullable.Int32](value).
+func (v Int32) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "null" or the value formatted with the given verb.
+func (v Int32) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs show the value as null or as the value.
+func (v Int32) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Int32) state() format.State {
+	return format.Of(true, v.IsPresent)
+}
@@ -0,0 +1,100 @@
+package payloads
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// definer is implemented by the pointers to the optional containers.
+type definer interface {
+	IsDefined() bool
+	SetDefined(isDefined bool)
+}
+
+// nullScanner walks a decoded payload along with the value it was decoded into, defining the optional
+// containers whose member is null. The code generated by easyjson skips null members instead of decoding them,
+// so that these containers would otherwise be left undefined, as if the member was absent.
+type nullScanner struct {
+	l jlexer.Lexer
+}
+
+// defineNulls defines the optional containers of v, a pointer, whose member is null in data.
+func defineNulls(data []byte, v reflect.Value) {
+	if !bytes.Contains(data, []byte("null")) {
+		return
+	}
+	s := nullScanner{l: jlexer.Lexer{Data: data}}
+	s.value(v)
+}
+
+func (s *nullScanner) value(v reflect.Value) {
+	for v.IsValid() {
+		if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		} else if kind, _ := fields.Container(v.Type()); kind != fields.Plain {
+			if fields.StateOf(v) != fields.Present {
+				v = reflect.Value{}
+			} else {
+				v = v.FieldByName("Value")
+			}
+		} else {
+			break
+		}
+	}
+	if !v.IsValid() || s.l.IsNull() {
+		s.l.SkipRecursive()
+		return
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && len(fields.Of(v.Type())) > 0 && s.l.IsDelim('{'):
+		s.object(func(name string) reflect.Value {
+			f, ok := fields.ByName(v.Type(), name)
+			if !ok {
+				return reflect.Value{}
+			}
+			fv, _ := v.FieldByIndexErr(f.Index)
+			return fv
+		})
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && s.l.IsDelim('{'):
+		s.object(func(name string) reflect.Value {
+			return v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		})
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && s.l.IsDelim('['):
+		s.l.Delim('[')
+		for i := 0; !s.l.IsDelim(']'); i++ {
+			if i < v.Len() {
+				s.value(v.Index(i))
+			} else {
+				s.l.SkipRecursive()
+			}
+			s.l.WantComma()
+		}
+		s.l.Delim(']')
+	default:
+		s.l.SkipRecursive()
+	}
+}
+
+func (s *nullScanner) object(member func(name string) reflect.Value) {
+	s.l.Delim('{')
+	for !s.l.IsDelim('}') {
+		name := s.l.String()
+		s.l.WantColon()
+
+		v := member(name)
+		if s.l.IsNull() && v.IsValid() && v.CanAddr() {
+			if kind, _ := fields.Container(v.Type()); kind == fields.Optional {
+				if d, ok := v.Addr().Interface().(definer); ok && !d.IsDefined() {
+					d.SetDefined(true)
+				}
+			}
+		}
+		s.value(v)
+		s.l.WantComma()
+	}
+	s.l.Delim('}')
+}
@@ -0,0 +1,113 @@
+package payloads_test
+
+import (
+	"testing"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/optional"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+type address struct {
+	City optional.String `json:"city"`
+}
+
+type person struct {
+	Name    optional.String           `json:"name"`
+	Age     optional.Int              `json:"age"`
+	Address optional.Object[*address] `json:"address"`
+	Friends []person                  `json:"friends"`
+}
+
+// UnmarshalEasyJSON decodes a person like the code generated by easyjson, which skips null members.
+func (v *person) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if l.IsNull() {
+			l.Skip()
+			l.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			v.Name.UnmarshalEasyJSON(l)
+		case "age":
+			v.Age.UnmarshalEasyJSON(l)
+		case "address":
+			v.Address.New = func() *address { return new(address) }
+			v.Address.UnmarshalEasyJSON(l)
+		case "friends":
+			l.Delim('[')
+			for !l.IsDelim(']') {
+				var friend person
+				friend.UnmarshalEasyJSON(l)
+				v.Friends = append(v.Friends, friend)
+				l.WantComma()
+			}
+			l.Delim(']')
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+func (v *address) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"city":`)
+	v.City.MarshalEasyJSON(w)
+	w.RawByte('}')
+}
+
+func (v *address) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if l.IsNull() {
+			l.Skip()
+		} else if key == "city" {
+			v.City.UnmarshalEasyJSON(l)
+		} else {
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+func TestDecoderDefinesNulls(t *testing.T) {
+	data := []byte(`{"name":null,"address":{"city":null},"friends":[{"age":null,"name":"b"}]}`)
+
+	var skipped person
+	if err := easyjson.Unmarshal(data, &skipped); err != nil {
+		t.Fatal(err)
+	}
+	if skipped.Name.IsDefined() {
+		t.Fatal("easyjson defined a null member, the decoder test is moot")
+	}
+
+	var v person
+	if err := (payloads.Decoder{}).Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Name.IsDefined() || v.Name.IsPresent {
+		t.Errorf("name = %+v, want defined as null", v.Name)
+	}
+	if v.Age.IsDefined() {
+		t.Errorf("age = %+v, want undefined", v.Age)
+	}
+	if city := v.Address.Value.City; !city.IsDefined() || city.IsPresent {
+		t.Errorf("address.city = %+v, want defined as null", city)
+	}
+	if age := v.Friends[0].Age; !age.IsDefined() || age.IsPresent {
+		t.Errorf("friends[0].age = %+v, want defined as null", age)
+	}
+	if name := v.Friends[0].Name; !name.IsDefined() || name.Value != "b" {
+		t.Errorf("friends[0].name = %+v, want b", name)
+	}
+}
@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/binadel/payloads/jsonschema"
+	"github.com/binadel/payloads/problem"
+)
+
+// StandardProblems are the statuses of the problem responses added by ProblemResponses by default,
+// which include the statuses of problem.FromDecodeError.
+var StandardProblems = []int{
+	http.StatusBadRequest,
+	http.StatusUnauthorized,
+	http.StatusForbidden,
+	http.StatusNotFound,
+	http.StatusConflict,
+	http.StatusRequestEntityTooLarge,
+	http.StatusUnprocessableEntity,
+	http.StatusInternalServerError,
+}
+
+const (
+	schemasRef   = "#/components/schemas/"
+	responsesRef = "#/components/responses/"
+)
+
+// Builder collects the components of a document.
+type Builder struct {
+	generator *jsonschema.Generator
+	responses map[string]*Response
+}
+
+// NewBuilder returns a builder without components.
+func NewBuilder() *Builder {
+	return &Builder{
+		generator: jsonschema.NewGenerator(schemasRef),
+		responses: make(map[string]*Response),
+	}
+}
+
+// Union adds the variants of a union registry, see jsonschema.Generator.Union.
+// It returns the builder so that calls can be chained.
+func (b *Builder) Union(registry jsonschema.Registry) *Builder {
+	b.generator.Union(registry)
+	return b
+}
+
+// Schema adds the schema of the type of v and of the struct types it uses to the components,
+// and returns the schema of the type, which references the components for a struct type.
+func (b *Builder) Schema(v any) *jsonschema.Schema {
+	return b.generator.Schema(v)
+}
+
+// ProblemResponse adds a response with the given status whose content is a problem.Details,
+// named after the status text such as NotFound, and returns a reference to it.
+func (b *Builder) ProblemResponse(status int) *Response {
+	name := responseName(status)
+	if _, ok := b.responses[name]; !ok {
+		b.responses[name] = &Response{
+			Description: http.StatusText(status),
+			Content: map[string]MediaType{
+				problem.MIMEProblemDetails: {Schema: b.Schema(problem.Details{})},
+			},
+		}
+	}
+	return &Response{Ref: responsesRef + name}
+}
+
+// ProblemResponses adds the problem responses of the given statuses, or of StandardProblems if there is none,
+// and returns references to them keyed by status code, ready to be used as the responses of an operation.
+func (b *Builder) ProblemResponses(statuses ...int) map[string]*Response {
+	if len(statuses) == 0 {
+		statuses = StandardProblems
+	}
+	refs := make(map[string]*Response, len(statuses))
+	for _, status := range statuses {
+		refs[strconv.Itoa(status)] = b.ProblemResponse(status)
+	}
+	return refs
+}
+
+// Components returns the components added so far.
+func (b *Builder) Components() *Components {
+	c := &Components{}
+	if defs := b.generator.Defs(); len(defs) > 0 {
+		c.Schemas = defs
+	}
+	if len(b.responses) > 0 {
+		c.Responses = b.responses
+	}
+	return c
+}
+
+var nonWord = regexp.MustCompile(`\W+`)
+
+func responseName(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "Status" + strconv.Itoa(status)
+	}
+	return nonWord.ReplaceAllString(text, "")
+}
@@ -0,0 +1,68 @@
+// Package openapi builds OpenAPI 3.1 documents from the payload types, describing their schemas
+// with the jsonschema package and the errors of an API as application/problem+json responses.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/binadel/payloads/jsonschema"
+)
+
+// Version is the version of the OpenAPI Specification of the documents.
+const Version = "3.1.0"
+
+// Document is the root object of an OpenAPI document, limited to its components.
+type Document struct {
+	OpenAPI    string      `json:"openapi"`
+	Info       Info        `json:"info"`
+	Components *Components `json:"components,omitempty"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the reusable objects of a document.
+type Components struct {
+	Schemas   map[string]*jsonschema.Schema `json:"schemas,omitempty"`
+	Responses map[string]*Response          `json:"responses,omitempty"`
+}
+
+// Response describes a response of an operation, or references one of the components with Ref.
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes the content of a response with a given media type.
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// JSON returns the document encoded as indented JSON.
+func (d Document) JSON() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// YAML returns the document encoded as YAML.
+func (d Document) YAML() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return toYAML(data)
+}
@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// node is a JSON value decoded with the order of the object members preserved.
+type node struct {
+	members []member // for an object
+	items   []node   // for an array
+	scalar  any      // for anything else: string, json.Number, bool or nil
+	kind    byte     // '{', '[' or 0 for a scalar
+}
+
+type member struct {
+	name  string
+	value node
+}
+
+// toYAML converts a JSON document to a YAML document, in block style.
+func toYAML(data []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	root, err := decodeNode(d)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	switch {
+	case root.kind == '{' && len(root.members) > 0:
+		writeMembers(&buf, root.members, 0, false)
+	case root.kind == '[' && len(root.items) > 0:
+		writeItems(&buf, root.items, 0)
+	default:
+		writeFlow(&buf, root)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeNode(d *json.Decoder) (node, error) {
+	token, err := d.Token()
+	if err != nil {
+		return node{}, err
+	}
+	switch token {
+	case json.Delim('{'):
+		n := node{kind: '{'}
+		for d.More() {
+			name, err := d.Token()
+			if err != nil {
+				return node{}, err
+			}
+			value, err := decodeNode(d)
+			if err != nil {
+				return node{}, err
+			}
+			n.members = append(n.members, member{name: name.(string), value: value})
+		}
+		_, err := d.Token()
+		return n, err
+	case json.Delim('['):
+		n := node{kind: '['}
+		for d.More() {
+			item, err := decodeNode(d)
+			if err != nil {
+				return node{}, err
+			}
+			n.items = append(n.items, item)
+		}
+		_, err := d.Token()
+		return n, err
+	default:
+		return node{scalar: token}, nil
+	}
+}
+
+// writeMembers writes the members of an object at the given indentation,
+// except for the first member if it follows the dash of a sequence item.
+func writeMembers(buf *bytes.Buffer, members []member, indent int, afterDash bool) {
+	for i, m := range members {
+		if i > 0 || !afterDash {
+			buf.WriteString(strings.Repeat(" ", indent))
+		}
+		writeString(buf, m.name)
+		buf.WriteByte(':')
+		writeNested(buf, m.value, indent+2)
+	}
+}
+
+func writeItems(buf *bytes.Buffer, items []node, indent int) {
+	for _, item := range items {
+		buf.WriteString(strings.Repeat(" ", indent))
+		buf.WriteString("- ")
+		switch {
+		case item.kind == '{' && len(item.members) > 0:
+			writeMembers(buf, item.members, indent+2, true)
+		case item.kind == '[' && len(item.items) > 0:
+			buf.WriteByte('\n')
+			writeItems(buf, item.items, indent+2)
+		default:
+			writeFlow(buf, item)
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// writeNested writes the value of a member, on the following lines if it is a non-empty object or array.
+func writeNested(buf *bytes.Buffer, n node, indent int) {
+	switch {
+	case n.kind == '{' && len(n.members) > 0:
+		buf.WriteByte('\n')
+		writeMembers(buf, n.members, indent, false)
+	case n.kind == '[' && len(n.items) > 0:
+		buf.WriteByte('\n')
+		writeItems(buf, n.items, indent)
+	default:
+		buf.WriteByte(' ')
+		writeFlow(buf, n)
+		buf.WriteByte('\n')
+	}
+}
+
+// writeFlow writes a scalar, or an empty object or array.
+func writeFlow(buf *bytes.Buffer, n node) {
+	switch n.kind {
+	case '{':
+		buf.WriteString("{}")
+	case '[':
+		buf.WriteString("[]")
+	default:
+		switch v := n.scalar.(type) {
+		case string:
+			writeString(buf, v)
+		case json.Number:
+			buf.WriteString(v.String())
+		case bool:
+			if v {
+				buf.WriteString("true")
+			} else {
+				buf.WriteString("false")
+			}
+		default:
+			buf.WriteString("null")
+		}
+	}
+}
+
+var plain = regexp.MustCompile(`^[A-Za-z_$][\w $./:()+-]*$`)
+
+// writeString writes a string without quotes if YAML reads it back as the same string, otherwise
+// as a JSON string, which is a valid YAML double-quoted scalar.
+func writeString(buf *bytes.Buffer, s string) {
+	if plain.MatchString(s) && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, ":") &&
+		!strings.Contains(s, ": ") && !reserved[strings.ToLower(s)] {
+		buf.WriteString(s)
+		return
+	}
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	_ = e.Encode(s)
+	buf.Truncate(buf.Len() - 1) // newline written by Encode
+}
+
+// reserved are the plain scalars that YAML reads as something else than a string.
+var reserved = map[string]bool{
+	"true": true, "false": true, "null": true, "yes": true, "no": true,
+	"on": true, "off": true, "y": true, "n": true,
+}
@@ -1,6 +1,14 @@
 package optional
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/format"
+)
 
 // AnyObject is a container for struct type that provides optional semantics without using pointers.
 // It uses encoding/json for marshaling and unmarshaling.
@@ -10,6 +18,8 @@ type AnyObject[T any] struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v AnyObject[T]) IsDefined() bool {
 	return v.isDefined
 }
@@ -19,6 +29,27 @@ func (v *AnyObject[T]) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil value is null.
+func (v *AnyObject[T]) Set(value T) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v AnyObject[T]) Lookup() (T, bool) {
+	return v.Value, !isNil(v.Value)
+}
+
+// Equal determines whether both values are undefined, or have the same JSON form.
+func (v AnyObject[T]) Equal(other AnyObject[T]) bool {
+	return v.isDefined == other.isDefined && equalJSON(v.Value, other.Value)
+}
+
+// Clone returns a deep copy of the value.
+func (v AnyObject[T]) Clone() AnyObject[T] {
+	v.Value = deepcopy.Copy(v.Value)
+	return v
+}
+
 // MarshalJSON implements a standard json marshaler interface.
 func (v AnyObject[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v.Value)
@@ -26,5 +57,35 @@ func (v AnyObject[T]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements a standard json unmarshaler interface.
 func (v *AnyObject[T]) UnmarshalJSON(data []byte) error {
+	if err := decode.LimitsJSON(data); err != nil {
+		return err
+	}
+	v.isDefined = true
 	return json.Unmarshal(data, &v.Value)
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v AnyObject[T]) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.AnyObject[T]](value).
+func (v AnyObject[T]) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v AnyObject[T]) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v AnyObject[T]) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v AnyObject[T]) state() format.State {
+	return format.Of(v.isDefined, !isNil(v.Value))
+}
@@ -1,6 +1,12 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
@@ -16,6 +22,8 @@ type Array[T easyjson.MarshalerUnmarshaler] struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Array[T]) IsDefined() bool {
 	return v.isDefined
 }
@@ -25,6 +33,38 @@ func (v *Array[T]) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil slice is null.
+func (v *Array[T]) Set(value []T) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Array[T]) Lookup() ([]T, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Equal determines whether both values are undefined, null, or have items with the same JSON form.
+func (v Array[T]) Equal(other Array[T]) bool {
+	if v.isDefined != other.isDefined || (v.Value == nil) != (other.Value == nil) || len(v.Value) != len(other.Value) {
+		return false
+	}
+	for i := range v.Value {
+		if isNil(v.Value[i]) != isNil(other.Value[i]) {
+			return false
+		}
+		if !isNil(v.Value[i]) && !equalEasyJSON(v.Value[i], other.Value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of the value.
+func (v Array[T]) Clone() Array[T] {
+	v.Value = deepcopy.Copy(v.Value)
+	return v
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Array[T]) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.Value == nil {
@@ -35,7 +75,7 @@ func (v Array[T]) MarshalEasyJSON(w *jwriter.Writer) {
 			if i > 0 {
 				w.RawByte(',')
 			}
-			if any(item) == nil {
+			if isNil(item) {
 				w.RawString("null")
 			} else {
 				item.MarshalEasyJSON(w)
@@ -47,13 +87,16 @@ func (v Array[T]) MarshalEasyJSON(w *jwriter.Writer) {
 
 // UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
 func (v *Array[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
 	if l.IsNull() {
 		l.Skip()
-		*v = Array[T]{}
+		*v = Array[T]{isDefined: true, New: v.New}
 	} else {
+		v.isDefined = true
 		v.Value = make([]T, 0)
 		l.Delim('[')
 		for !l.IsDelim(']') {
+			decode.Item(l, len(v.Value))
 			var item T
 			if l.IsNull() {
 				l.Skip()
@@ -84,3 +127,29 @@ func (v *Array[T]) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Array[T]) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Array[T]](value).
+func (v Array[T]) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Array[T]) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Array[T]) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Array[T]) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
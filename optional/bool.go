@@ -3,6 +3,11 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -15,6 +20,8 @@ type Bool struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Bool) IsDefined() bool {
 	return v.isDefined
 }
@@ -39,6 +46,16 @@ func (v *Bool) Set(value bool) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Bool) Lookup() (bool, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are undefined, null, or have the same value.
+func (v Bool) Equal(other Bool) bool {
+	return v.isDefined == other.isDefined && v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Bool) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
@@ -52,10 +69,11 @@ func (v Bool) MarshalEasyJSON(w *jwriter.Writer) {
 func (v *Bool) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	if l.IsNull() {
 		l.Skip()
-		*v = Bool{}
+		*v = Bool{isDefined: true}
 	} else {
-		v.Value = l.Bool()
+		v.Value = decode.Bool(l)
 		v.IsPresent = true
+		v.isDefined = true
 	}
 }
 
@@ -72,3 +90,29 @@ func (v *Bool) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Bool) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Bool](value).
+func (v Bool) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Bool) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Bool) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Bool) state() format.State {
+	return format.Of(v.isDefined, v.IsPresent)
+}
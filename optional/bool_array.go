@@ -3,6 +3,12 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -14,6 +20,8 @@ type BoolArray struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v BoolArray) IsDefined() bool {
 	return v.isDefined
 }
@@ -23,6 +31,27 @@ func (v *BoolArray) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil slice is null.
+func (v *BoolArray) Set(value []bool) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v BoolArray) Lookup() ([]bool, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Equal determines whether both values are undefined, null, or have the same items.
+func (v BoolArray) Equal(other BoolArray) bool {
+	return v.isDefined == other.isDefined && (v.Value == nil) == (other.Value == nil) && slices.Equal(v.Value, other.Value)
+}
+
+// Clone returns a copy of the value that does not share its items.
+func (v BoolArray) Clone() BoolArray {
+	v.Value = slices.Clone(v.Value)
+	return v
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v BoolArray) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.Value == nil {
@@ -41,18 +70,21 @@ func (v BoolArray) MarshalEasyJSON(w *jwriter.Writer) {
 
 // UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
 func (v *BoolArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
 	if l.IsNull() {
 		l.Skip()
-		*v = BoolArray{}
+		*v = BoolArray{isDefined: true}
 	} else {
+		v.isDefined = true
 		v.Value = make([]bool, 0)
 		l.Delim('[')
 		for !l.IsDelim(']') {
+			decode.Item(l, len(v.Value))
 			var item bool
 			if l.IsNull() {
 				l.Skip()
 			} else {
-				item = l.Bool()
+				item = decode.Bool(l)
 			}
 			v.Value = append(v.Value, item)
 			l.WantComma()
@@ -74,3 +106,29 @@ func (v *BoolArray) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v BoolArray) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.BoolArray](value).
+func (v BoolArray) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v BoolArray) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v BoolArray) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v BoolArray) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
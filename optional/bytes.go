@@ -0,0 +1,119 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Bytes is a container for byte slice type that provides optional semantics without using pointers.
+// It is encoded as a base64 string using Encoding, or the standard padded encoding if Encoding is nil.
+// Decoding accepts the standard and URL-safe alphabets, with or without padding,
+// and fails with a payloads.LimitError if the data is longer than the MaxBytesLength limit, see payloads.Limits.
+type Bytes struct {
+	isDefined bool
+	Value     []byte
+	Encoding  *base64.Encoding
+}
+
+// IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
+func (v Bytes) IsDefined() bool {
+	return v.isDefined
+}
+
+// SetDefined is the setter for isDefined, see IsDefined.
+func (v *Bytes) SetDefined(isDefined bool) {
+	v.isDefined = isDefined
+}
+
+// Set stores the value, a nil slice is null.
+func (v *Bytes) Set(value []byte) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Bytes) Lookup() ([]byte, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Equal determines whether both values are undefined, null, or have the same data.
+func (v Bytes) Equal(other Bytes) bool {
+	return v.isDefined == other.isDefined && (v.Value == nil) == (other.Value == nil) && bytes.Equal(v.Value, other.Value)
+}
+
+// Clone returns a copy of the value that does not share its data.
+func (v Bytes) Clone() Bytes {
+	v.Value = slices.Clone(v.Value)
+	return v
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (v Bytes) MarshalEasyJSON(w *jwriter.Writer) {
+	if v.Value == nil {
+		w.RawString("null")
+	} else if v.Encoding == nil || v.Encoding == base64.StdEncoding {
+		w.Base64Bytes(v.Value)
+	} else {
+		w.String(v.Encoding.EncodeToString(v.Value))
+	}
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (v *Bytes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*v = Bytes{isDefined: true, Encoding: v.Encoding}
+	} else {
+		v.Value = decode.Bytes(l)
+		v.isDefined = true
+	}
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (v Bytes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (v *Bytes) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Bytes) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Bytes](value).
+func (v Bytes) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Bytes) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Bytes) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Bytes) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
@@ -0,0 +1,33 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// equalEasyJSON determines whether both values have the same JSON form.
+func equalEasyJSON(a, b easyjson.Marshaler) bool {
+	wa, wb := jwriter.Writer{}, jwriter.Writer{}
+	a.MarshalEasyJSON(&wa)
+	b.MarshalEasyJSON(&wb)
+	if wa.Error != nil || wb.Error != nil {
+		return false
+	}
+	return bytes.Equal(wa.Buffer.BuildBytes(), wb.Buffer.BuildBytes())
+}
+
+// equalJSON determines whether both values have the same JSON form, using encoding/json.
+func equalJSON(a, b any) bool {
+	da, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	db, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(da, db)
+}
@@ -0,0 +1,198 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/optional"
+	"github.com/binadel/payloads/union"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// point is a minimal easyjson struct for the Object, Array and Union containers.
+type point struct {
+	X, Y int
+}
+
+func (p *point) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"x":` + strconv.Itoa(p.X) + `,"y":` + strconv.Itoa(p.Y) + `}`)
+}
+
+func (p *point) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		switch key {
+		case "x":
+			p.X = l.Int()
+		case "y":
+			p.Y = l.Int()
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+// origin has the same fields as point, but is a different variant of the union.
+type origin struct {
+	point
+}
+
+var shapes = union.NewRegistry[easyjson.MarshalerUnmarshaler]("kind").
+	Register("point", func() easyjson.MarshalerUnmarshaler { return new(point) }).
+	Register("origin", func() easyjson.MarshalerUnmarshaler { return new(origin) })
+
+func newPoint() *point { return new(point) }
+
+func unionOf(v easyjson.MarshalerUnmarshaler) optional.Union[easyjson.MarshalerUnmarshaler] {
+	u := optional.Union[easyjson.MarshalerUnmarshaler]{Registry: shapes}
+	u.Set(v)
+	u.SetDefined(true)
+	return u
+}
+
+// roundTrip checks that value is equal to itself, to its decoded encoding, and to its clone if it has one.
+// The empty value is decoded into, so that it can hold the constructors that decoding needs.
+func roundTrip[T interface {
+	Equal(T) bool
+	easyjson.Marshaler
+}, P interface {
+	*T
+	easyjson.Unmarshaler
+}](value, empty T, clone func(T) T) func(*testing.T) {
+	return func(t *testing.T) {
+		if !value.Equal(value) {
+			t.Fatalf("%v is not equal to itself", value)
+		}
+
+		data, err := payloads.Encoder{NonFinite: payloads.NonFiniteString}.Marshal(value)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		decoded := empty
+		if err := (payloads.Decoder{AcceptNonFinite: true}).Unmarshal(data, P(&decoded)); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if !value.Equal(decoded) || !decoded.Equal(value) {
+			t.Errorf("%v is not equal to %v decoded from %s", value, decoded, data)
+		}
+
+		if clone == nil {
+			return
+		}
+		c := clone(value)
+		if !value.Equal(c) || !c.Equal(value) {
+			t.Errorf("%v is not equal to its clone %v", value, c)
+		}
+		cloned, err := payloads.Encoder{NonFinite: payloads.NonFiniteString}.Marshal(c)
+		if err != nil {
+			t.Fatalf("Marshal clone: %v", err)
+		}
+		if !bytes.Equal(data, cloned) {
+			t.Errorf("clone is encoded as %s, want %s", cloned, data)
+		}
+	}
+}
+
+func TestEqualRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(*testing.T)
+	}{
+		{"Int", roundTrip(optional.Of[optional.Int](42), optional.Int{}, nil)},
+		{"IntNull", roundTrip(optional.Null[optional.Int](), optional.Int{}, nil)},
+		{"Int64String", roundTrip(optional.Of[optional.Int64String, int64](1<<60), optional.Int64String{}, nil)},
+		{"String", roundTrip(optional.Of[optional.String]("café"), optional.String{}, nil)},
+		{"Float64", roundTrip(optional.Of[optional.Float64](0.1), optional.Float64{}, nil)},
+		{"Float64NaN", roundTrip(optional.Of[optional.Float64](math.NaN()), optional.Float64{}, nil)},
+		{"Float32Inf", roundTrip(optional.Of[optional.Float32, float32](float32(math.Inf(-1))), optional.Float32{}, nil)},
+		{"Float64Array", roundTrip(
+			optional.Of[optional.Float64Array]([]float64{1, math.NaN()}), optional.Float64Array{}, optional.Float64Array.Clone)},
+		{"Float64ArrayNull", roundTrip(optional.Null[optional.Float64Array](), optional.Float64Array{}, optional.Float64Array.Clone)},
+		{"StringArrayEmpty", roundTrip(optional.Of[optional.StringArray]([]string{}), optional.StringArray{}, optional.StringArray.Clone)},
+		{"Number", roundTrip(optional.Of[optional.Number, json.Number]("1.50"), optional.Number{}, nil)},
+		{"Bytes", roundTrip(optional.Of[optional.Bytes]([]byte("data")), optional.Bytes{}, optional.Bytes.Clone)},
+		{"Raw", roundTrip(optional.Of[optional.Raw, json.RawMessage]([]byte(`{"a":[1,2]}`)), optional.Raw{}, optional.Raw.Clone)},
+		{"Object", roundTrip(
+			optional.Of[optional.Object[*point]](&point{1, 2}),
+			optional.Object[*point]{New: newPoint},
+			optional.Object[*point].Clone)},
+		{"ObjectNull", roundTrip(
+			optional.Null[optional.Object[*point]](),
+			optional.Object[*point]{New: newPoint},
+			optional.Object[*point].Clone)},
+		{"Array", roundTrip(
+			optional.Of[optional.Array[*point]]([]*point{{1, 2}, nil}),
+			optional.Array[*point]{New: newPoint},
+			optional.Array[*point].Clone)},
+		{"Union", roundTrip(
+			unionOf(&point{1, 2}),
+			optional.Union[easyjson.MarshalerUnmarshaler]{Registry: shapes},
+			optional.Union[easyjson.MarshalerUnmarshaler].Clone)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
+
+func TestEqualDiffers(t *testing.T) {
+	tests := []struct {
+		name  string
+		equal bool
+	}{
+		{"UndefinedNull", optional.Undefined[optional.Int]().Equal(optional.Null[optional.Int]())},
+		{"NullZero", optional.Null[optional.Int]().Equal(optional.Of[optional.Int](0))},
+		{"Value", optional.Of[optional.Int](1).Equal(optional.Of[optional.Int](2))},
+		{"NaNZero", optional.Of[optional.Float64](math.NaN()).Equal(optional.Of[optional.Float64, float64](0))},
+		{"ArrayNullEmpty", optional.Null[optional.IntArray]().Equal(optional.Of[optional.IntArray]([]int{}))},
+		{"ArrayItems", optional.Of[optional.Float64Array]([]float64{math.NaN()}).Equal(optional.Of[optional.Float64Array]([]float64{1}))},
+		{"ObjectFields", optional.Of[optional.Object[*point]](&point{1, 2}).Equal(optional.Of[optional.Object[*point]](&point{2, 1}))},
+		{"UnionVariant", unionOf(&point{}).Equal(unionOf(&origin{}))},
+	}
+	for _, tt := range tests {
+		if tt.equal {
+			t.Errorf("%s: values are equal", tt.name)
+		}
+	}
+}
+
+func TestUnionEqualWithoutRegistry(t *testing.T) {
+	var a, b optional.Union[easyjson.MarshalerUnmarshaler]
+	a.Set(&point{1, 2})
+	b.Set(&point{1, 2})
+	if !a.Equal(b) {
+		t.Error("unions holding the same value are not equal")
+	}
+}
+
+func TestCloneDoesNotShare(t *testing.T) {
+	array := optional.Of[optional.Array[*point]]([]*point{{1, 2}})
+	clonedArray := array.Clone()
+	clonedArray.Value[0].X = 3
+	if array.Equal(clonedArray) || array.Value[0].X != 1 {
+		t.Error("the clone of an Array shares its items")
+	}
+
+	object := optional.Of[optional.Object[*point]](&point{1, 2})
+	clonedObject := object.Clone()
+	clonedObject.Value.Y = 3
+	if object.Equal(clonedObject) || object.Value.Y != 2 {
+		t.Error("the clone of an Object shares its value")
+	}
+
+	floats := optional.Of[optional.Float64Array]([]float64{1})
+	clonedFloats := floats.Clone()
+	clonedFloats.Value[0] = 2
+	if floats.Equal(clonedFloats) || floats.Value[0] != 1 {
+		t.Error("the clone of a Float64Array shares its items")
+	}
+}
@@ -3,6 +3,12 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/encode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -15,6 +21,8 @@ type Float32 struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Float32) IsDefined() bool {
 	return v.isDefined
 }
@@ -39,10 +47,20 @@ func (v *Float32) Set(value float32) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Float32) Lookup() (float32, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are undefined, null, or have the same value.
+func (v Float32) Equal(other Float32) bool {
+	return v.isDefined == other.isDefined && v.IsPresent == other.IsPresent && (!v.IsPresent || encode.Same(v.Value, other.Value))
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Float32) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
-		w.Float32(v.Value)
+		encode.Float32(w, v.Value)
 	} else {
 		w.RawString("null")
 	}
@@ -52,10 +70,11 @@ func (v Float32) MarshalEasyJSON(w *jwriter.Writer) {
 func (v *Float32) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	if l.IsNull() {
 		l.Skip()
-		*v = Float32{}
+		*v = Float32{isDefined: true}
 	} else {
-		v.Value = l.Float32()
+		v.Value = decode.Float32(l)
 		v.IsPresent = true
+		v.isDefined = true
 	}
 }
 
@@ -72,3 +91,29 @@ func (v *Float32) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Float32) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Float32](value).
+func (v Float32) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Float32) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Float32) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Float32) state() format.State {
+	return format.Of(v.isDefined, v.IsPresent)
+}
@@ -3,6 +3,13 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/encode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -14,6 +21,8 @@ type Float64Array struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Float64Array) IsDefined() bool {
 	return v.isDefined
 }
@@ -23,6 +32,27 @@ func (v *Float64Array) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil slice is null.
+func (v *Float64Array) Set(value []float64) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Float64Array) Lookup() ([]float64, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Equal determines whether both values are undefined, null, or have the same items.
+func (v Float64Array) Equal(other Float64Array) bool {
+	return v.isDefined == other.isDefined && (v.Value == nil) == (other.Value == nil) && slices.EqualFunc(v.Value, other.Value, encode.Same[float64])
+}
+
+// Clone returns a copy of the value that does not share its items.
+func (v Float64Array) Clone() Float64Array {
+	v.Value = slices.Clone(v.Value)
+	return v
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Float64Array) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.Value == nil {
@@ -33,7 +63,7 @@ func (v Float64Array) MarshalEasyJSON(w *jwriter.Writer) {
 			if i > 0 {
 				w.RawByte(',')
 			}
-			w.Float64(item)
+			encode.Float64(w, item)
 		}
 		w.RawByte(']')
 	}
@@ -41,18 +71,21 @@ func (v Float64Array) MarshalEasyJSON(w *jwriter.Writer) {
 
 // UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
 func (v *Float64Array) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
 	if l.IsNull() {
 		l.Skip()
-		*v = Float64Array{}
+		*v = Float64Array{isDefined: true}
 	} else {
+		v.isDefined = true
 		v.Value = make([]float64, 0)
 		l.Delim('[')
 		for !l.IsDelim(']') {
+			decode.Item(l, len(v.Value))
 			var item float64
 			if l.IsNull() {
 				l.Skip()
 			} else {
-				item = l.Float64()
+				item = decode.Float64(l)
 			}
 			v.Value = append(v.Value, item)
 			l.WantComma()
@@ -74,3 +107,29 @@ func (v *Float64Array) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Float64Array) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Float64Array](value).
+func (v Float64Array) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Float64Array) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Float64Array) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Float64Array) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
@@ -0,0 +1,120 @@
+package optional
+
+// Container is implemented by the optional types holding a value of type T.
+type Container[T any] interface {
+	IsDefined() bool
+	Lookup() (T, bool)
+}
+
+// ContainerPtr is implemented by pointers to the optional types holding a value of type T,
+// it allows the helpers below to create containers of type O.
+type ContainerPtr[O any, T any] interface {
+	*O
+	Container[T]
+	Set(value T)
+	SetDefined(isDefined bool)
+}
+
+// Of returns a defined container of type O holding the value, e.g. Of[Int](5).
+// Untyped constants need the type of the value as well, e.g. Of[Int64, int64](5).
+func Of[O any, T any, P ContainerPtr[O, T]](value T) O {
+	var o O
+	P(&o).Set(value)
+	P(&o).SetDefined(true)
+	return o
+}
+
+// Null returns a defined container of type O that is null, e.g. Null[Int]().
+func Null[O any, P interface {
+	*O
+	SetDefined(isDefined bool)
+}]() O {
+	var o O
+	P(&o).SetDefined(true)
+	return o
+}
+
+// Undefined returns an undefined container of type O, which is its zero value.
+func Undefined[O any]() O {
+	var o O
+	return o
+}
+
+// FromPtr returns a defined container of type O holding the value pointed to by ptr, or null if ptr is nil.
+func FromPtr[O any, T any, P ContainerPtr[O, T]](ptr *T) O {
+	if ptr == nil {
+		return Null[O, P]()
+	}
+	return Of[O, T, P](*ptr)
+}
+
+// ToPtr returns a pointer to a copy of the value of v, or nil if it is undefined or null.
+func ToPtr[T any](v Container[T]) *T {
+	if value, ok := v.Lookup(); ok {
+		return &value
+	}
+	return nil
+}
+
+// Map returns a container of type R holding the result of f applied to the value of v, e.g. Map[String](age, strconv.Itoa).
+// An undefined or null v results in an undefined or null container, without calling f.
+func Map[R any, T any, U any, P ContainerPtr[R, U]](v Container[T], f func(T) U) R {
+	if !v.IsDefined() {
+		return Undefined[R]()
+	}
+	value, ok := v.Lookup()
+	if !ok {
+		return Null[R, P]()
+	}
+	return Of[R, U, P](f(value))
+}
+
+// FlatMap returns the container returned by f for the value of v.
+// An undefined or null v results in an undefined or null container, without calling f.
+func FlatMap[R any, T any, P interface {
+	*R
+	SetDefined(isDefined bool)
+}](v Container[T], f func(T) R) R {
+	if !v.IsDefined() {
+		return Undefined[R]()
+	}
+	value, ok := v.Lookup()
+	if !ok {
+		return Null[R, P]()
+	}
+	return f(value)
+}
+
+// OrElse returns the value of v, or the given value if it is undefined or null.
+func OrElse[T any](v Container[T], value T) T {
+	if present, ok := v.Lookup(); ok {
+		return present
+	}
+	return value
+}
+
+// OrElseFunc returns the value of v, or the result of f if it is undefined or null.
+func OrElseFunc[T any](v Container[T], f func() T) T {
+	if present, ok := v.Lookup(); ok {
+		return present
+	}
+	return f()
+}
+
+// Filter returns v if it is undefined, null, or its value satisfies the predicate, otherwise it returns null.
+func Filter[O any, T any, P ContainerPtr[O, T]](v O, predicate func(T) bool) O {
+	if value, ok := P(&v).Lookup(); !ok || predicate(value) {
+		return v
+	}
+	return Null[O, P]()
+}
+
+// All returns an iterator over the value of v, which yields nothing if it is undefined or null.
+// It has the shape of the iter.Seq type of later Go versions, and can be called directly with a yield function.
+func All[T any](v Container[T]) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		if value, ok := v.Lookup(); ok {
+			yield(value)
+		}
+	}
+}
@@ -3,6 +3,11 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -15,6 +20,8 @@ type Int struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Int) IsDefined() bool {
 	return v.isDefined
 }
@@ -39,6 +46,16 @@ func (v *Int) Set(value int) {
 	v.Value = value
 }
 
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Int) Lookup() (int, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are undefined, null, or have the same value.
+func (v Int) Equal(other Int) bool {
+	return v.isDefined == other.isDefined && v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Int) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.IsPresent {
@@ -52,10 +69,11 @@ func (v Int) MarshalEasyJSON(w *jwriter.Writer) {
 func (v *Int) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	if l.IsNull() {
 		l.Skip()
-		*v = Int{}
+		*v = Int{isDefined: true}
 	} else {
-		v.Value = l.Int()
+		v.Value = decode.Int(l)
 		v.IsPresent = true
+		v.isDefined = true
 	}
 }
 
@@ -72,3 +90,29 @@ func (v *Int) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Int) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Int](value).
+func (v Int) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Int) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Int) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Int) state() format.State {
+	return format.Of(v.isDefined, v.IsPresent)
+}
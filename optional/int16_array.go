@@ -3,6 +3,12 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
 )
@@ -14,6 +20,8 @@ type Int16Array struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Int16Array) IsDefined() bool {
 	return v.isDefined
 }
@@ -23,6 +31,27 @@ func (v *Int16Array) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil slice is null.
+func (v *Int16Array) Set(value []int16) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Int16Array) Lookup() ([]int16, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Equal determines whether both values are undefined, null, or have the same items.
+func (v Int16Array) Equal(other Int16Array) bool {
+	return v.isDefined == other.isDefined && (v.Value == nil) == (other.Value == nil) && slices.Equal(v.Value, other.Value)
+}
+
+// Clone returns a copy of the value that does not share its items.
+func (v Int16Array) Clone() Int16Array {
+	v.Value = slices.Clone(v.Value)
+	return v
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Int16Array) MarshalEasyJSON(w *jwriter.Writer) {
 	if v.Value == nil {
@@ -41,18 +70,21 @@ func (v Int16Array) MarshalEasyJSON(w *jwriter.Writer) {
 
 // UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
 func (v *Int16Array) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
 	if l.IsNull() {
 		l.Skip()
-		*v = Int16Array{}
+		*v = Int16Array{isDefined: true}
 	} else {
+		v.isDefined = true
 		v.Value = make([]int16, 0)
 		l.Delim('[')
 		for !l.IsDelim(']') {
+			decode.Item(l, len(v.Value))
 			var item int16
 			if l.IsNull() {
 				l.Skip()
 			} else {
-				item = l.Int16()
+				item = decode.Int16(l)
 			}
 			v.Value = append(v.Value, item)
 			l.WantComma()
@@ -74,3 +106,29 @@ func (v *Int16Array) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Int16Array) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Int16Array](value).
+func (v Int16Array) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Int16Array) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Int16Array) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Int16Array) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
@@ -0,0 +1,52 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/binadel/payloads/optional"
+	"github.com/mailru/easyjson"
+)
+
+func TestInt64StringDecode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: `"12"`, want: 12},
+		{in: `"-12"`, want: -12},
+		{in: `12`, want: 12},
+		{in: `"9007199254740993"`, want: 9007199254740993},
+		{in: `"+12"`, wantErr: true},
+		{in: `"012"`, wantErr: true},
+		{in: `" 12"`, wantErr: true},
+		{in: `""`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var v optional.Int64String
+			err := easyjson.Unmarshal([]byte(tt.in), &v)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %v, want an error", tt.in, v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.IsPresent || v.Value != tt.want {
+				t.Errorf("Unmarshal(%s) = %v, want %d", tt.in, v, tt.want)
+			}
+		})
+	}
+}
+
+func TestUInt64StringDecode(t *testing.T) {
+	for _, in := range []string{`"+12"`, `"-1"`, `"012"`} {
+		var v optional.UInt64String
+		if err := easyjson.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", in, v)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package optional
+
+import "reflect"
+
+// isNil determines whether the value of a generic container is nil,
+// which is not the case for a nil pointer converted to any.
+func isNil[T any](value T) bool {
+	if any(value) == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,150 @@
+package optional
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/binadel/payloads/internal/number"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Number is a container for an arbitrary-precision number that provides optional semantics without using pointers.
+// It keeps the exact literal of the JSON number, so it is re-encoded exactly as it was received.
+type Number struct {
+	isDefined bool
+	IsPresent bool
+	Value     json.Number
+}
+
+// IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
+func (v Number) IsDefined() bool {
+	return v.isDefined
+}
+
+// SetDefined is the setter for isDefined, see IsDefined.
+func (v *Number) SetDefined(isDefined bool) {
+	v.isDefined = isDefined
+}
+
+// Get returns the value if it is not null, otherwise it returns the given default value.
+func (v Number) Get(value json.Number) json.Number {
+	if v.IsPresent {
+		return v.Value
+	} else {
+		return value
+	}
+}
+
+// Set stores the value and sets it as not null.
+func (v *Number) Set(value json.Number) {
+	v.IsPresent = true
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Number) Lookup() (json.Number, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are undefined, null, or have the same literal.
+func (v Number) Equal(other Number) bool {
+	return v.isDefined == other.isDefined && v.IsPresent == other.IsPresent && (!v.IsPresent || v.Value == other.Value)
+}
+
+// Int64 converts the value to int64, failing if it is null, fractional or out of range.
+func (v Number) Int64() (int64, error) {
+	return number.Int64(string(v.Value))
+}
+
+// Float64 converts the value to the nearest float64, failing if it is null or out of range.
+func (v Number) Float64() (float64, error) {
+	return number.Float64(string(v.Value))
+}
+
+// BigInt converts the value to an exact integer, failing if it is null or fractional.
+func (v Number) BigInt() (*big.Int, error) {
+	return number.Int(string(v.Value))
+}
+
+// BigRat converts the value to an exact rational number, failing if it is null.
+func (v Number) BigRat() (*big.Rat, error) {
+	return number.Rat(string(v.Value))
+}
+
+// Decimal converts the value to an unscaled integer with scale fractional digits, e.g. 12.34 with scale 2 is 1234.
+// It fails if the value is null or has more fractional digits than scale.
+func (v Number) Decimal(scale int) (*big.Int, error) {
+	return number.Decimal(string(v.Value), scale)
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (v Number) MarshalEasyJSON(w *jwriter.Writer) {
+	if v.IsPresent {
+		if !number.Valid(string(v.Value)) {
+			w.Error = fmt.Errorf("optional: invalid number literal %q", v.Value)
+			return
+		}
+		w.RawString(string(v.Value))
+	} else {
+		w.RawString("null")
+	}
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (v *Number) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*v = Number{isDefined: true}
+	} else {
+		v.Value = decode.Number(l)
+		v.IsPresent = true
+		v.isDefined = true
+	}
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (v Number) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (v *Number) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Number) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Number](value).
+func (v Number) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Number) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Number) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Number) state() format.State {
+	return format.Of(v.isDefined, v.IsPresent)
+}
@@ -1,6 +1,12 @@
 package optional
 
 import (
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/format"
 	"github.com/mailru/easyjson"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mailru/easyjson/jwriter"
@@ -16,6 +22,8 @@ type Object[T easyjson.MarshalerUnmarshaler] struct {
 }
 
 // IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
 func (v Object[T]) IsDefined() bool {
 	return v.isDefined
 }
@@ -25,9 +33,33 @@ func (v *Object[T]) SetDefined(isDefined bool) {
 	v.isDefined = isDefined
 }
 
+// Set stores the value, a nil value is null.
+func (v *Object[T]) Set(value T) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Object[T]) Lookup() (T, bool) {
+	return v.Value, !isNil(v.Value)
+}
+
+// Equal determines whether both values are undefined, null, or have the same JSON form.
+func (v Object[T]) Equal(other Object[T]) bool {
+	if v.isDefined != other.isDefined || isNil(v.Value) != isNil(other.Value) {
+		return false
+	}
+	return isNil(v.Value) || equalEasyJSON(v.Value, other.Value)
+}
+
+// Clone returns a deep copy of the value.
+func (v Object[T]) Clone() Object[T] {
+	v.Value = deepcopy.Copy(v.Value)
+	return v
+}
+
 // MarshalEasyJSON does JSON marshaling using easyjson interface.
 func (v Object[T]) MarshalEasyJSON(w *jwriter.Writer) {
-	if any(v.Value) == nil {
+	if isNil(v.Value) {
 		w.RawString("null")
 	} else {
 		v.Value.MarshalEasyJSON(w)
@@ -36,11 +68,13 @@ func (v Object[T]) MarshalEasyJSON(w *jwriter.Writer) {
 
 // UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
 func (v *Object[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
 	if l.IsNull() {
 		l.Skip()
-		*v = Object[T]{}
+		*v = Object[T]{isDefined: true, New: v.New}
 	} else {
-		if any(v.Value) == nil {
+		v.isDefined = true
+		if isNil(v.Value) {
 			if v.New == nil {
 				panic("Cannot instantiate generic type from nil constructor, set New function to define the constructor")
 			}
@@ -63,3 +97,29 @@ func (v *Object[T]) UnmarshalJSON(data []byte) error {
 	v.UnmarshalEasyJSON(&l)
 	return l.Error()
 }
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Object[T]) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Object[T]](value).
+func (v Object[T]) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Object[T]) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Object[T]) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Object[T]) state() format.State {
+	return format.Of(v.isDefined, !isNil(v.Value))
+}
@@ -0,0 +1,144 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Raw is a container for a JSON value that is passed through without being interpreted,
+// it provides optional semantics without using pointers.
+// The value is checked to be valid JSON when decoding, and written back byte for byte.
+type Raw struct {
+	isDefined bool
+	Value     json.RawMessage
+}
+
+// IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
+func (v Raw) IsDefined() bool {
+	return v.isDefined
+}
+
+// SetDefined is the setter for isDefined, see IsDefined.
+func (v *Raw) SetDefined(isDefined bool) {
+	v.isDefined = isDefined
+}
+
+// Set stores the value, a nil value is null.
+func (v *Raw) Set(value json.RawMessage) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Raw) Lookup() (json.RawMessage, bool) {
+	return v.Value, v.Value != nil
+}
+
+// Decode decodes the value into target, using easyjson if target implements its unmarshaler interface.
+// A null value leaves target unchanged.
+func (v Raw) Decode(target any) error {
+	if v.Value == nil {
+		return nil
+	}
+	if u, ok := target.(easyjson.Unmarshaler); ok {
+		return easyjson.Unmarshal(v.Value, u)
+	}
+	return json.Unmarshal(v.Value, target)
+}
+
+// Equal determines whether both values are undefined, null, or have the same bytes.
+func (v Raw) Equal(other Raw) bool {
+	return v.isDefined == other.isDefined && (v.Value == nil) == (other.Value == nil) && bytes.Equal(v.Value, other.Value)
+}
+
+// Clone returns a copy of the value that does not share its bytes.
+func (v Raw) Clone() Raw {
+	v.Value = slices.Clone(v.Value)
+	return v
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (v Raw) MarshalEasyJSON(w *jwriter.Writer) {
+	if v.Value == nil {
+		w.RawString("null")
+	} else if !json.Valid(v.Value) {
+		w.Raw(nil, errors.New("optional: invalid raw JSON value"))
+	} else {
+		w.Raw(v.Value, nil)
+	}
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (v *Raw) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
+	if l.IsNull() {
+		l.Skip()
+		*v = Raw{isDefined: true}
+	} else {
+		raw := l.Raw()
+		if !l.Ok() {
+			return
+		}
+		if !json.Valid(raw) {
+			l.AddError(&jlexer.LexerError{
+				Reason: "invalid raw JSON value",
+				Offset: l.GetPos() - len(raw),
+				Data:   string(raw),
+			})
+			return
+		}
+		v.Value = slices.Clone(raw)
+		v.isDefined = true
+	}
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (v Raw) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (v *Raw) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// String returns "undefined", "null" or the JSON text of the value.
+func (v Raw) String() string {
+	return format.String(v.state(), string(v.Value))
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Raw](value).
+func (v Raw) GoString() string {
+	return format.GoString(v, v.state(), string(v.Value))
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the JSON text of the value formatted with the given verb.
+func (v Raw) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), string(v.Value))
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the JSON text of the value otherwise.
+func (v Raw) LogValue() slog.Value {
+	return format.LogValue(v.state(), string(v.Value))
+}
+
+// state returns the JSON-level state of the value.
+func (v Raw) state() format.State {
+	return format.Of(v.isDefined, v.Value != nil)
+}
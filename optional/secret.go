@@ -0,0 +1,139 @@
+package optional
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/encode"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Secret is a container for a sensitive string, such as a password or a token, that provides optional semantics without using pointers.
+// It decodes like String, but its value is masked as "[REDACTED]" when it is printed, logged or reported in a decoding error,
+// and also when it is encoded, unless it has been marked as exposable with SetExposed or the payloads.Encoder exposes secrets.
+type Secret struct {
+	isDefined bool
+	isExposed bool
+	IsPresent bool
+	Value     string
+}
+
+// IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
+func (v Secret) IsDefined() bool {
+	return v.isDefined
+}
+
+// SetDefined is the setter for isDefined, see IsDefined.
+func (v *Secret) SetDefined(isDefined bool) {
+	v.isDefined = isDefined
+}
+
+// IsExposed determines whether the real value is written in the json output, instead of being masked.
+func (v Secret) IsExposed() bool {
+	return v.isExposed
+}
+
+// SetExposed is the setter for isExposed, see IsExposed.
+func (v *Secret) SetExposed(isExposed bool) {
+	v.isExposed = isExposed
+}
+
+// Get returns the value if it is not null, otherwise it returns the given default value.
+func (v Secret) Get(value string) string {
+	if v.IsPresent {
+		return v.Value
+	} else {
+		return value
+	}
+}
+
+// Set stores the value and sets it as not null.
+func (v *Secret) Set(value string) {
+	v.IsPresent = true
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns the zero value and false.
+func (v Secret) Lookup() (string, bool) {
+	return v.Value, v.IsPresent
+}
+
+// Equal determines whether both values are undefined, null, or have the same value.
+// The values are compared in constant time.
+func (v Secret) Equal(other Secret) bool {
+	return v.isDefined == other.isDefined && v.IsPresent == other.IsPresent &&
+		subtle.ConstantTimeCompare([]byte(v.Value), []byte(other.Value)) == 1
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (v Secret) MarshalEasyJSON(w *jwriter.Writer) {
+	if !v.IsPresent {
+		w.RawString("null")
+	} else if v.isExposed || encode.Exposed(w) {
+		w.String(v.Value)
+	} else {
+		w.String(format.Redacted)
+	}
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (v *Secret) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*v = Secret{isDefined: true}
+	} else {
+		v.Value = decode.Secret(l)
+		v.IsPresent = true
+		v.isDefined = true
+	}
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (v Secret) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (v *Secret) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// String returns "undefined", "null" or "[REDACTED]".
+func (v Secret) String() string {
+	return format.String(v.state(), format.Redacted)
+}
+
+// GoString returns the expression that creates the value, with the value masked.
+func (v Secret) GoString() string {
+	return format.GoString(v, v.state(), format.Redacted)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or "[REDACTED]" whatever the verb.
+func (v Secret) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, v.GoString())
+	} else {
+		fmt.Fprint(f, v.String())
+	}
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as "[REDACTED]" otherwise.
+func (v Secret) LogValue() slog.Value {
+	return format.LogValue(v.state(), format.Redacted)
+}
+
+// state returns the JSON-level state of the value.
+func (v Secret) state() format.State {
+	return format.Of(v.isDefined, v.IsPresent)
+}
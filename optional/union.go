@@ -0,0 +1,129 @@
+package optional
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/binadel/payloads/internal/deepcopy"
+	"github.com/binadel/payloads/internal/format"
+	"github.com/binadel/payloads/union"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Union is a container for a polymorphic object that provides optional semantics without using pointers.
+// The variant of the object is selected by its discriminator member, using the variants of Registry,
+// and the discriminator is written back when encoding. The generic argument V is usually an interface
+// implemented by pointers to the variant structs.
+type Union[V easyjson.MarshalerUnmarshaler] struct {
+	isDefined bool
+	Value     V
+	Registry  *union.Registry[V]
+}
+
+// IsDefined determines whether this field should be included in the json output, if it has the omitempty tag.
+// It is set when the field is decoded, even from a null value. The code generated by easyjson skips null members
+// though, so a struct with generated code must be decoded with payloads.Decoder to tell a null member from an absent one.
+func (v Union[V]) IsDefined() bool {
+	return v.isDefined
+}
+
+// SetDefined is the setter for isDefined, see IsDefined.
+func (v *Union[V]) SetDefined(isDefined bool) {
+	v.isDefined = isDefined
+}
+
+// Set stores the value, a nil value is null.
+func (v *Union[V]) Set(value V) {
+	v.Value = value
+}
+
+// Lookup returns the value and true if it is not null, otherwise it returns nil and false.
+func (v Union[V]) Lookup() (V, bool) {
+	return v.Value, !isNil(v.Value)
+}
+
+// Equal determines whether both values are undefined, null, or have the same JSON form.
+func (v Union[V]) Equal(other Union[V]) bool {
+	if v.isDefined != other.isDefined || isNil(v.Value) != isNil(other.Value) {
+		return false
+	}
+	// The variants are compared directly, so that a missing Registry does not make the encoding panic.
+	return isNil(v.Value) || reflect.TypeOf(v.Value) == reflect.TypeOf(other.Value) && equalEasyJSON(v.Value, other.Value)
+}
+
+// Clone returns a deep copy of the value, which shares the registry.
+func (v Union[V]) Clone() Union[V] {
+	v.Value = deepcopy.Copy(v.Value)
+	return v
+}
+
+// MarshalEasyJSON does JSON marshaling using easyjson interface.
+func (v Union[V]) MarshalEasyJSON(w *jwriter.Writer) {
+	if isNil(v.Value) {
+		w.RawString("null")
+	} else {
+		if v.Registry == nil {
+			panic("Cannot encode union without registry, set Registry to define the variants")
+		}
+		v.Registry.Encode(w, v.Value)
+	}
+}
+
+// UnmarshalEasyJSON does JSON unmarshaling using easyjson interface.
+func (v *Union[V]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
+	if l.IsNull() {
+		l.Skip()
+		*v = Union[V]{isDefined: true, Registry: v.Registry}
+	} else {
+		if v.Registry == nil {
+			panic("Cannot decode union without registry, set Registry to define the variants")
+		}
+		v.Value = v.Registry.Decode(l)
+		v.isDefined = true
+	}
+}
+
+// MarshalJSON implements a standard json marshaler interface.
+func (v Union[V]) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	v.MarshalEasyJSON(&w)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// UnmarshalJSON implements a standard json unmarshaler interface.
+func (v *Union[V]) UnmarshalJSON(data []byte) error {
+	l := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&l)
+	return l.Error()
+}
+
+// String returns "undefined", "null" or the value formatted with %v.
+func (v Union[V]) String() string {
+	return format.String(v.state(), v.Value)
+}
+
+// GoString returns the expression that creates the value, such as optional.Of[optional.Union[V]](value).
+func (v Union[V]) GoString() string {
+	return format.GoString(v, v.state(), v.Value)
+}
+
+// Format implements fmt.Formatter, it prints "undefined", "null" or the value formatted with the given verb.
+func (v Union[V]) Format(f fmt.State, verb rune) {
+	format.Format(f, verb, v, v.state(), v.Value)
+}
+
+// LogValue implements slog.LogValuer, so that structured logs omit the value if it is undefined,
+// and show it as null or as the value otherwise.
+func (v Union[V]) LogValue() slog.Value {
+	return format.LogValue(v.state(), v.Value)
+}
+
+// state returns the JSON-level state of the value.
+func (v Union[V]) state() format.State {
+	return format.Of(v.isDefined, !isNil(v.Value))
+}
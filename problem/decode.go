@@ -0,0 +1,22 @@
+package problem
+
+import (
+	"github.com/binadel/payloads/internal/decode"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// The unmarshalers of Details are written by hand rather than generated, so that they enforce the limits
+// of the payloads.Decoder decoding them, or payloads.DefaultLimits, when the details are the whole payload.
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Details) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	v.UnmarshalEasyJSON(&r)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Details) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	decode.Limits(l)
+	easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem1(l, v)
+}
@@ -25,4 +25,17 @@ type Details struct {
 	// A URI reference that identifies the specific occurrence of the problem.
 	// It may or may not yield further information if dereferenced.
 	Instance string `json:"instance"`
+
+	// An extension member listing the individual problems found in the request content,
+	// used by validation problems.
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error is a single problem listed in the errors member of Details.
+type Error struct {
+	// A human-readable explanation of this problem.
+	Detail string `json:"detail"`
+
+	// A JSON Pointer to the offending value in the request content.
+	Pointer string `json:"pointer"`
 }
@@ -17,7 +17,83 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(in *jlexer.Lexer, out *Details) {
+func easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(in *jlexer.Lexer, out *Error) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "detail":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Detail = string(in.String())
+			}
+		case "pointer":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Pointer = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(out *jwriter.Writer, in Error) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Detail))
+	}
+	{
+		const prefix string = ",\"pointer\":"
+		out.RawString(prefix)
+		out.String(string(in.Pointer))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Error) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Error) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(l, v)
+}
+func easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem1(in *jlexer.Lexer, out *Details) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -61,6 +137,33 @@ func easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(in *jlexer.Lexer, out
 			} else {
 				out.Instance = string(in.String())
 			}
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]Error, 0, 2)
+					} else {
+						out.Errors = []Error{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Error
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Errors = append(out.Errors, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -71,7 +174,7 @@ func easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(out *jwriter.Writer, in Details) {
+func easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem1(out *jwriter.Writer, in Details) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -100,29 +203,31 @@ func easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(out *jwriter.Writer, i
 		out.RawString(prefix)
 		out.String(string(in.Instance))
 	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Errors {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Details) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(&w, v)
+	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Details) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *Details) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Details) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC5354e0DecodeGithubComBinadelPayloadsProblem(l, v)
+	easyjsonC5354e0EncodeGithubComBinadelPayloadsProblem1(w, v)
 }
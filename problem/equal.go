@@ -0,0 +1,19 @@
+package problem
+
+import "slices"
+
+// Equal determines whether both details have the same members.
+func (v Details) Equal(other Details) bool {
+	return v.Type == other.Type &&
+		v.Title == other.Title &&
+		v.Status == other.Status &&
+		v.Detail == other.Detail &&
+		v.Instance == other.Instance &&
+		slices.Equal(v.Errors, other.Errors)
+}
+
+// Clone returns a copy of the details that does not share its errors.
+func (v Details) Clone() Details {
+	v.Errors = slices.Clone(v.Errors)
+	return v
+}
@@ -0,0 +1,79 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/binadel/payloads"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// Validation returns the details of a request whose content is not valid, listing the given errors.
+func Validation(errs ...Error) Details {
+	return Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Detail: "The request content is not valid.",
+		Errors: errs,
+	}
+}
+
+// FromDecodeError returns the details of a request whose content, data, failed to decode with err.
+// A payloads.TypeError, payloads.LimitError or payloads.FieldError results in a validation problem
+// pointing at the offending value, with one entry for each of the joined errors. An exceeded MaxBytes limit
+// results in a content too large problem, and any other error, such as a syntax error, in a bad request problem.
+func FromDecodeError(data []byte, err error) Details {
+	var limitErr *payloads.LimitError
+	if errors.As(err, &limitErr) && limitErr.Limit == "MaxBytes" {
+		return Details{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusRequestEntityTooLarge),
+			Status: http.StatusRequestEntityTooLarge,
+			Detail: limitErr.Error(),
+		}
+	}
+
+	if errs := decodeErrors(data, err); len(errs) > 0 {
+		return Validation(errs...)
+	}
+
+	d := Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusBadRequest),
+		Status: http.StatusBadRequest,
+		Detail: "The request content is not valid JSON.",
+	}
+	var lexerErr *jlexer.LexerError
+	if errors.As(err, &lexerErr) {
+		d.Errors = []Error{{
+			Detail:  lexerErr.Reason,
+			Pointer: payloads.Locate(data, lexerErr.Offset),
+		}}
+	}
+	return d
+}
+
+// decodeErrors converts the validation errors among err and the errors joined in it.
+func decodeErrors(data []byte, err error) []Error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []Error
+		for _, err := range joined.Unwrap() {
+			errs = append(errs, decodeErrors(data, err)...)
+		}
+		return errs
+	}
+
+	var typeErr *payloads.TypeError
+	var limitErr *payloads.LimitError
+	var fieldErr *payloads.FieldError
+	switch {
+	case errors.As(err, &typeErr):
+		return []Error{{Detail: typeErr.Error(), Pointer: payloads.Locate(data, typeErr.Offset)}}
+	case errors.As(err, &limitErr):
+		return []Error{{Detail: limitErr.Error(), Pointer: payloads.Locate(data, limitErr.Offset)}}
+	case errors.As(err, &fieldErr):
+		return []Error{{Detail: fieldErr.Error(), Pointer: fieldErr.Pointer}}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package sqlpatch builds the SET clause of an SQL UPDATE statement from a patch struct of optional fields,
+// setting only the columns of the fields that are defined.
+package sqlpatch
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/fields"
+)
+
+// ErrNoChanges is returned when no field is defined, since an UPDATE statement needs at least one column.
+var ErrNoChanges = errors.New("sqlpatch: no field is defined")
+
+// Style is the style of the placeholders of the query arguments, which depends on the database driver.
+type Style int
+
+const (
+	// Dollar is the numbered style of PostgreSQL drivers: $1, $2...
+	Dollar Style = iota
+
+	// Question is the positional style of MySQL and SQLite drivers: ?, ?...
+	Question
+)
+
+// Placeholder returns the placeholder of the n-th argument of a query, counting from 1.
+func (s Style) Placeholder(n int) string {
+	if s == Question {
+		return "?"
+	}
+	return "$" + strconv.Itoa(n)
+}
+
+// Set returns the SET clause, without the SET keyword, assigning the defined fields of the patch v,
+// a struct or a pointer to one, and the arguments of its placeholders, such as "name = $2, email = NULL".
+// The placeholders are numbered after the offset arguments that come before the clause in the statement,
+// as in "UPDATE users SET " + set + " WHERE id = $1" with an offset of 1 and the id as first argument.
+//
+// The column of a field is given by its db tag, such as `db:"email"`, or is its JSON member name.
+// Fields tagged with `db:"-"` are skipped, as are the fields that are not payload containers.
+// An optional field is set if it is defined, and a nullable field if it is present. A null field
+// is set to NULL, and a present field to a placeholder whose argument is the value returned by Lookup.
+// It returns ErrNoChanges if there is no field to set.
+//
+// The columns are written as they are, so Set fails if one is not an unquoted identifier, such as first_name,
+// which a db tag can provide. It also fails for the fields that do not hold column values, even if they are
+// undefined: the values must be booleans, numbers, strings, byte slices, time.Time or driver.Valuer
+// implementations, so other fields, such as an optional.Object, must be skipped with `db:"-"`.
+func Set(v any, style Style, offset int) (string, []any, error) {
+	s, ok := diff.Struct(reflect.ValueOf(v))
+	if !ok {
+		return "", nil, fmt.Errorf("sqlpatch: %T is not a struct", v)
+	}
+	var b strings.Builder
+	var args []any
+	for _, f := range fields.Of(s.Type()) {
+		column, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		if column == "-" {
+			continue
+		}
+		if column == "" {
+			column = f.Name
+		}
+		kind, value := fields.Container(f.Type)
+		if kind == fields.Plain {
+			continue
+		}
+		if !identifier.MatchString(column) {
+			return "", nil, fmt.Errorf("sqlpatch: column %q of field %s is not an unquoted identifier", column, f.Name)
+		}
+		if !isColumnValue(value) {
+			return "", nil, fmt.Errorf("sqlpatch: field %s holds a %s, which is not a column value", f.Name, value)
+		}
+		fv, err := s.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+
+		state := fields.StateOf(fv)
+		if state == fields.Undefined || (state == fields.Null && kind == fields.Nullable) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(column)
+		b.WriteString(" = ")
+		if state == fields.Null {
+			b.WriteString("NULL")
+			continue
+		}
+		args = append(args, fv.MethodByName("Lookup").Call(nil)[0].Interface())
+		b.WriteString(style.Placeholder(offset + len(args)))
+	}
+	if b.Len() == 0 {
+		return "", nil, ErrNoChanges
+	}
+	return b.String(), args, nil
+}
+
+var (
+	identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	valuer     = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// isColumnValue determines whether a value of type t can be the argument of a placeholder.
+func isColumnValue(t reflect.Type) bool {
+	if t == timeType || t.Implements(valuer) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	default:
+		return false
+	}
+}
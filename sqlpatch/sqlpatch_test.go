@@ -0,0 +1,220 @@
+package sqlpatch_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/optional"
+	"github.com/binadel/payloads/sqlpatch"
+)
+
+// recorder is a database/sql driver that records the statements it executes instead of running them.
+type recorder struct {
+	query string
+	args  []driver.Value
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return conn{r}, nil }
+
+type conn struct{ r *recorder }
+
+func (c conn) Prepare(query string) (driver.Stmt, error) { return stmt{c.r, query}, nil }
+func (c conn) Close() error                              { return nil }
+func (c conn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type stmt struct {
+	r     *recorder
+	query string
+}
+
+func (s stmt) Close() error { return nil }
+
+// NumInput counts the placeholders of both styles, so that database/sql checks the number of arguments.
+func (s stmt) NumInput() int { return strings.Count(s.query, "$") + strings.Count(s.query, "?") }
+
+func (s stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.r.query, s.r.args = s.query, args
+	return driver.RowsAffected(1), nil
+}
+
+func (s stmt) Query([]driver.Value) (driver.Rows, error) { return nil, errors.New("not supported") }
+
+var rec = &recorder{}
+
+func init() {
+	sql.Register("sqlpatch-recorder", rec)
+}
+
+type userPatch struct {
+	Name     optional.String   `json:"name"`
+	Email    optional.String   `json:"email" db:"email_address"`
+	Age      optional.Int      `json:"age"`
+	Bio      nullable.String   `json:"bio"`
+	Avatar   optional.Bytes    `json:"avatar"`
+	Password optional.Secret   `json:"password" db:"-"`
+	Friends  optional.IntArray `json:"friends" db:"-"`
+	ID       int               `json:"id"`
+}
+
+// decoded returns the patch decoded from data, which is sent by a client.
+func decoded(data string) userPatch {
+	var patch userPatch
+	if err := json.Unmarshal([]byte(data), &patch); err != nil {
+		panic(err)
+	}
+	return patch
+}
+
+// undefined returns a patch whose name holds a value without being defined.
+func undefined() userPatch {
+	var patch userPatch
+	patch.Name.Set("Ada")
+	patch.Age = optional.Of[optional.Int](36)
+	return patch
+}
+
+func TestSetExec(t *testing.T) {
+	db, err := sql.Open("sqlpatch-recorder", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name   string
+		patch  userPatch
+		style  sqlpatch.Style
+		offset int
+		where  string
+		// before and after are the arguments of the statement that come before and after the SET clause.
+		before, after []any
+		query         string
+		args          []driver.Value
+	}{
+		{
+			name: "DollarWithOffset",
+			patch: userPatch{
+				Name:     optional.Of[optional.String]("Ada"),
+				Age:      optional.Of[optional.Int](36),
+				Password: optional.Of[optional.Secret]("hunter2"),
+				ID:       9,
+			},
+			style:  sqlpatch.Dollar,
+			offset: 1,
+			where:  " WHERE id = $1",
+			before: []any{7},
+			query:  "UPDATE users SET name = $2, age = $3 WHERE id = $1",
+			args:   []driver.Value{int64(7), "Ada", int64(36)},
+		},
+		{
+			name: "DollarWithoutOffset",
+			patch: userPatch{
+				Email: optional.Of[optional.String]("ada@example.com"),
+				Bio:   nullable.Of[nullable.String]("Analyst"),
+			},
+			style: sqlpatch.Dollar,
+			where: " WHERE id = $3",
+			after: []any{7},
+			query: "UPDATE users SET email_address = $1, bio = $2 WHERE id = $3",
+			args:  []driver.Value{"ada@example.com", "Analyst", int64(7)},
+		},
+		{
+			name: "QuestionNull",
+			patch: userPatch{
+				Email:  optional.Null[optional.String](),
+				Age:    optional.Of[optional.Int](37),
+				Avatar: optional.Of[optional.Bytes]([]byte{0xff}),
+			},
+			style: sqlpatch.Question,
+			where: " WHERE id = ?",
+			after: []any{7},
+			query: "UPDATE users SET email_address = NULL, age = ?, avatar = ? WHERE id = ?",
+			args:  []driver.Value{int64(37), []byte{0xff}, int64(7)},
+		},
+		{
+			name: "NullableNullSkipped",
+			patch: userPatch{
+				Name: optional.Null[optional.String](),
+				Bio:  nullable.Null[nullable.String](),
+			},
+			style: sqlpatch.Question,
+			where: " WHERE id = 7",
+			query: "UPDATE users SET name = NULL WHERE id = 7",
+		},
+		{
+			name:  "UndefinedSkipped",
+			patch: undefined(),
+			style: sqlpatch.Dollar,
+			where: " WHERE id = 7",
+			query: "UPDATE users SET age = $1 WHERE id = 7",
+			args:  []driver.Value{int64(36)},
+		},
+		{
+			name:  "Decoded",
+			patch: decoded(`{"name":null,"age":36,"bio":null}`),
+			style: sqlpatch.Dollar,
+			where: " WHERE id = 7",
+			query: "UPDATE users SET name = NULL, age = $1 WHERE id = 7",
+			args:  []driver.Value{int64(36)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, args, err := sqlpatch.Set(&tt.patch, tt.style, tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			all := append(append(append([]any{}, tt.before...), args...), tt.after...)
+			if _, err := db.Exec("UPDATE users SET "+set+tt.where, all...); err != nil {
+				t.Fatalf("Exec: %v", err)
+			}
+			if rec.query != tt.query {
+				t.Errorf("query = %q, want %q", rec.query, tt.query)
+			}
+			if len(rec.args) != len(tt.args) || (len(tt.args) > 0 && !reflect.DeepEqual(rec.args, tt.args)) {
+				t.Errorf("args = %#v, want %#v", rec.args, tt.args)
+			}
+		})
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	type object struct {
+		Name optional.String `json:"name"`
+	}
+	tests := []struct {
+		name  string
+		patch any
+		want  string
+	}{
+		{"NoChanges", &userPatch{ID: 1}, sqlpatch.ErrNoChanges.Error()},
+		{"NoDefinedChanges", &userPatch{Name: optional.String{IsPresent: true, Value: "Ada"}}, sqlpatch.ErrNoChanges.Error()},
+		{"NotStruct", []int{1}, "is not a struct"},
+		{"Column", &struct {
+			FirstName optional.String `json:"first-name"`
+		}{}, `column "first-name" of field first-name is not an unquoted identifier`},
+		{"QuotedColumn", &struct {
+			Name optional.String `json:"name" db:"name; DROP TABLE users"`
+		}{}, "is not an unquoted identifier"},
+		{"Array", &struct {
+			Friends optional.IntArray `json:"friends"`
+		}{}, "field friends holds a []int, which is not a column value"},
+		{"Object", &struct {
+			Owner optional.AnyObject[*object] `json:"owner"`
+		}{}, "which is not a column value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := sqlpatch.Set(tt.patch, sqlpatch.Dollar, 0)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Set() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package payloads
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/jsonpointer"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+var (
+	// ErrUnknownField is reported by a Decoder that disallows unknown fields.
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrDuplicateField is reported by a Decoder that disallows duplicate fields.
+	ErrDuplicateField = errors.New("duplicate field")
+)
+
+// FieldError describes an object member rejected by a strict Decoder.
+// Err is either ErrUnknownField or ErrDuplicateField, so it can be checked with errors.Is.
+type FieldError struct {
+	// Name is the name of the member.
+	Name string
+
+	// Pointer is the JSON Pointer of the member.
+	Pointer string
+
+	// Offset is the position of the member name in the input.
+	Offset int
+
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v %q at %q", e.Err, e.Name, e.Pointer)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// strictScanner walks a payload along with the Go type it is decoded into,
+// collecting the members that are unknown to the type or repeated.
+type strictScanner struct {
+	l          jlexer.Lexer
+	unknown    bool
+	duplicates bool
+	errs       []error
+}
+
+// checkStrict reports every unknown or duplicate member of data, when decoded into t.
+func checkStrict(data []byte, t reflect.Type, unknown, duplicates bool) error {
+	s := strictScanner{l: jlexer.Lexer{Data: data}, unknown: unknown, duplicates: duplicates}
+	s.value(t, "")
+	return errors.Join(s.errs...)
+}
+
+func (s *strictScanner) value(t reflect.Type, pointer string) {
+	for t != nil {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		} else if kind, value := fields.Container(t); kind != fields.Plain {
+			t = value
+		} else {
+			break
+		}
+	}
+	if t == nil || s.l.IsNull() {
+		s.l.SkipRecursive()
+		return
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && len(fields.Of(t)) > 0 && s.l.IsDelim('{'):
+		s.object(pointer, func(name string) (reflect.Type, bool) {
+			f, ok := fields.ByName(t, name)
+			return f.Type, ok
+		})
+	case t.Kind() == reflect.Map && s.l.IsDelim('{'):
+		s.object(pointer, func(string) (reflect.Type, bool) {
+			return t.Elem(), true
+		})
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && s.l.IsDelim('['):
+		s.l.Delim('[')
+		for i := 0; !s.l.IsDelim(']'); i++ {
+			s.value(t.Elem(), pointer+"/"+strconv.Itoa(i))
+			s.l.WantComma()
+		}
+		s.l.Delim(']')
+	default:
+		s.l.SkipRecursive()
+	}
+}
+
+func (s *strictScanner) object(pointer string, member func(name string) (reflect.Type, bool)) {
+	seen := make(map[string]bool)
+	s.l.Delim('{')
+	for !s.l.IsDelim('}') {
+		raw := s.l.Raw()
+		offset := s.l.GetPos() - len(raw)
+		key := jlexer.Lexer{Data: raw}
+		name := key.String()
+		s.l.WantColon()
+
+		memberPointer := pointer + "/" + jsonpointer.Escape(name)
+		if s.duplicates && seen[name] {
+			s.errs = append(s.errs, &FieldError{Name: name, Pointer: memberPointer, Offset: offset, Err: ErrDuplicateField})
+		}
+		seen[name] = true
+
+		t, ok := member(name)
+		if !ok {
+			if s.unknown {
+				s.errs = append(s.errs, &FieldError{Name: name, Pointer: memberPointer, Offset: offset, Err: ErrUnknownField})
+			}
+			s.l.SkipRecursive()
+		} else {
+			s.value(t, memberPointer)
+		}
+		s.l.WantComma()
+	}
+	s.l.Delim('}')
+}
@@ -0,0 +1,176 @@
+// Package typescript generates TypeScript declarations of the payload types, so that clients share
+// the shape of the payloads: an optional field is declared as field?: T | null, a nullable one as field: T | null,
+// or field?: T | null with the omitempty option.
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/typemap"
+)
+
+// Generator declares TypeScript interfaces for Go struct types, and for the struct types they use.
+type Generator struct {
+	names map[reflect.Type]string
+	taken map[string]bool
+	order []reflect.Type
+	types typemap.Mapper
+}
+
+// NewGenerator returns a generator without declarations.
+func NewGenerator() *Generator {
+	return &Generator{
+		names: make(map[reflect.Type]string),
+		taken: make(map[string]bool),
+	}
+}
+
+// Registry is implemented by union.Registry, whatever its type argument.
+type Registry = typemap.Registry
+
+// Union adds the variants of a union registry, so that the optional.Union and nullable.Union containers
+// of the same type argument are declared as a union of the variants. Without it, they are declared as object.
+// It returns the generator so that calls can be chained.
+func (g *Generator) Union(registry Registry) *Generator {
+	if !g.types.AddUnion(registry) {
+		panic(fmt.Sprintf("typescript: %T is not a union registry", registry))
+	}
+	return g
+}
+
+// Add declares the types of the given values, which are structs or pointers to structs.
+// It returns the generator so that calls can be chained.
+func (g *Generator) Add(values ...any) *Generator {
+	for _, v := range values {
+		g.typeName(reflect.TypeOf(v))
+	}
+	return g
+}
+
+// Generate returns the declarations of the added types and of the struct types they use, in order of use.
+// problem.Details is declared as the ProblemDetails interface.
+func (g *Generator) Generate() string {
+	var b strings.Builder
+	for i := 0; i < len(g.order); i++ {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		t := g.order[i]
+		if t == typemap.ProblemDetails {
+			b.WriteString(problemDetails)
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", g.names[t])
+		for _, f := range fields.Of(t) {
+			mark := ""
+			if typemap.Omissible(f) {
+				mark = "?"
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", propertyName(f.Name), mark, g.typeName(f.Type))
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// problemDetails is the declaration of problem.Details.
+const problemDetails = `export interface ProblemDetails {
+  type: string;
+  title: string;
+  status: number;
+  detail: string;
+  instance: string;
+  errors?: ProblemError[];
+}
+
+export interface ProblemError {
+  detail: string;
+  pointer: string;
+}
+`
+
+// typeName returns the TypeScript type of t, declaring the struct types it uses.
+func (g *Generator) typeName(t reflect.Type) string {
+	shape := g.types.Of(t)
+	name := g.shapeName(shape)
+	if shape.Null {
+		return orNull(name)
+	}
+	return name
+}
+
+func (g *Generator) shapeName(shape typemap.Shape) string {
+	switch shape.Kind {
+	case typemap.Boolean:
+		return "boolean"
+	case typemap.Integer, typemap.Number:
+		return "number"
+	case typemap.String, typemap.DateTime, typemap.Base64, typemap.IntegerString:
+		return "string"
+	case typemap.Array:
+		return arrayOf(g.typeName(shape.Elem))
+	case typemap.Map:
+		return fmt.Sprintf("Record<string, %s>", g.typeName(shape.Elem))
+	case typemap.Struct:
+		return g.declare(shape.Elem)
+	case typemap.Union:
+		return g.unionName(shape)
+	default:
+		return "unknown"
+	}
+}
+
+func (g *Generator) unionName(shape typemap.Shape) string {
+	if shape.Variants == nil {
+		return "object"
+	}
+	variants := make([]string, len(shape.Variants))
+	for i, v := range shape.Variants {
+		quoted, _ := json.Marshal(v.Key)
+		variants[i] = fmt.Sprintf("(%s & { %s: %s })", g.typeName(v.Type), propertyName(shape.Discriminator), quoted)
+	}
+	return strings.Join(variants, " | ")
+}
+
+// declare returns the name of the interface of the struct type t, adding it to the declarations if needed.
+func (g *Generator) declare(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := typemap.Name(t, func(name string) bool {
+		return g.taken[name] || name == "ProblemError" || name == "ProblemDetails"
+	})
+	g.names[t] = name
+	g.taken[name] = true
+	g.order = append(g.order, t)
+	return name
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+func propertyName(name string) string {
+	if identifier.MatchString(name) {
+		return name
+	}
+	quoted, _ := json.Marshal(name)
+	return string(quoted)
+}
+
+func orNull(name string) string {
+	if strings.HasSuffix(name, " | null") || name == "unknown" {
+		return name
+	}
+	return name + " | null"
+}
+
+func arrayOf(name string) string {
+	if strings.ContainsAny(name, " |&") {
+		return "(" + name + ")[]"
+	}
+	return name + "[]"
+}
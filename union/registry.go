@@ -0,0 +1,204 @@
+// Package union implements polymorphic payloads, whose variant is selected by a discriminator member,
+// such as {"kind":"card",...} and {"kind":"bank",...}. See optional.Union and nullable.Union.
+package union
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads"
+	"github.com/binadel/payloads/internal/scope"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// Registry maps the discriminator values of a polymorphic payload to the constructors of its variants.
+// The generic argument V is usually an interface implemented by pointers to the variant structs.
+// A Registry is meant to be filled during initialization, it is not safe to register variants concurrently with decoding.
+type Registry[V easyjson.MarshalerUnmarshaler] struct {
+	discriminator string
+	variants      map[string]func() V
+	keys          map[reflect.Type]string
+}
+
+// NewRegistry returns an empty registry whose variants are selected by the given member name.
+func NewRegistry[V easyjson.MarshalerUnmarshaler](discriminator string) *Registry[V] {
+	return &Registry[V]{
+		discriminator: discriminator,
+		variants:      make(map[string]func() V),
+		keys:          make(map[reflect.Type]string),
+	}
+}
+
+// Discriminator returns the name of the member that selects the variant.
+func (r *Registry[V]) Discriminator() string {
+	return r.discriminator
+}
+
+// Register adds a variant that is selected by the given discriminator value, and created by the constructor.
+// It returns the registry, so that calls can be chained.
+func (r *Registry[V]) Register(key string, constructor func() V) *Registry[V] {
+	r.variants[key] = constructor
+	r.keys[reflect.TypeOf(constructor())] = key
+	return r
+}
+
+// Key returns the discriminator value of the given variant, and false if its type is not registered.
+func (r *Registry[V]) Key(value V) (string, bool) {
+	key, ok := r.keys[reflect.TypeOf(value)]
+	return key, ok
+}
+
+// Variants returns the types of the registered variants, keyed by their discriminator value.
+func (r *Registry[V]) Variants() map[string]reflect.Type {
+	variants := make(map[string]reflect.Type, len(r.keys))
+	for t, key := range r.keys {
+		variants[key] = t
+	}
+	return variants
+}
+
+// New returns a new variant for the given discriminator value, and false if it is not registered.
+func (r *Registry[V]) New(key string) (V, bool) {
+	constructor, ok := r.variants[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return constructor(), true
+}
+
+// Encode writes the variant as a JSON object, adding the discriminator member first
+// unless the variant already writes it.
+func (r *Registry[V]) Encode(w *jwriter.Writer, value V) {
+	key, ok := r.Key(value)
+	if !ok {
+		if w.Error == nil {
+			w.Error = fmt.Errorf("union: variant %T is not registered", value)
+		}
+		return
+	}
+
+	var vw jwriter.Writer
+	if opts := scope.Lookup(w); opts != nil {
+		defer scope.Bind(&vw, opts)()
+	}
+	value.MarshalEasyJSON(&vw)
+	data, err := vw.BuildBytes()
+	if err != nil {
+		if w.Error == nil {
+			w.Error = err
+		}
+		return
+	}
+
+	_, found, isObject := r.scan(data)
+	switch {
+	case !isObject:
+		if w.Error == nil {
+			w.Error = fmt.Errorf("union: variant %T is not encoded as an object", value)
+		}
+	case found:
+		w.Raw(data, nil)
+	default:
+		w.RawByte('{')
+		w.String(r.discriminator)
+		w.RawByte(':')
+		w.String(key)
+		if rest := data[1:]; len(rest) > 0 && rest[0] != '}' {
+			w.RawByte(',')
+		}
+		w.Raw(data[1:], nil)
+	}
+}
+
+// Decode reads a JSON object and decodes it into the variant selected by its discriminator member,
+// which can be anywhere in the object. A missing or unknown discriminator is reported as a payloads.TypeError.
+func (r *Registry[V]) Decode(l *jlexer.Lexer) V {
+	var zero V
+	raw := l.Raw()
+	if !l.Ok() {
+		return zero
+	}
+	offset := l.GetPos() - len(raw)
+
+	key, found, isObject := r.scan(raw)
+	if !isObject {
+		l.AddError(r.typeError(string(raw), offset, payloads.ErrKind))
+		return zero
+	}
+	if !found {
+		l.AddError(r.typeError("{...}", offset, payloads.ErrDiscriminator))
+		return zero
+	}
+	value, ok := r.New(key)
+	if !ok {
+		l.AddError(r.typeError(fmt.Sprintf("%q", key), offset, payloads.ErrDiscriminator))
+		return zero
+	}
+
+	vl := jlexer.Lexer{Data: raw}
+	if opts := scope.Lookup(l); opts != nil {
+		defer scope.Bind(&vl, opts)()
+	}
+	value.UnmarshalEasyJSON(&vl)
+	if err := vl.Error(); err != nil {
+		l.AddError(shift(err, offset))
+	}
+	return value
+}
+
+// scan looks for the discriminator member at the top level of data, returning its value if it is found,
+// and whether data is an object at all.
+func (r *Registry[V]) scan(data []byte) (key string, found bool, isObject bool) {
+	l := jlexer.Lexer{Data: data}
+	if !l.IsDelim('{') {
+		return "", false, false
+	}
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		name := l.UnsafeFieldName(false)
+		l.WantColon()
+		if name == r.discriminator && l.CurrentToken() == jlexer.TokenString {
+			return l.String(), true, true
+		}
+		l.SkipRecursive()
+		l.WantComma()
+	}
+	l.Delim('}')
+	return "", false, l.Ok()
+}
+
+func (r *Registry[V]) typeError(literal string, offset int, err error) error {
+	return &payloads.TypeError{
+		GoType:  reflect.TypeOf((*V)(nil)).Elem().String(),
+		Literal: literal,
+		Offset:  offset,
+		Err:     err,
+	}
+}
+
+// shift moves the offset of an error reported while decoding a variant,
+// from the start of the variant to the start of the whole input.
+func shift(err error, offset int) error {
+	var typeErr *payloads.TypeError
+	var limitErr *payloads.LimitError
+	var lexerErr *jlexer.LexerError
+	switch {
+	case errors.As(err, &typeErr):
+		shifted := *typeErr
+		shifted.Offset += offset
+		return &shifted
+	case errors.As(err, &limitErr):
+		shifted := *limitErr
+		shifted.Offset += offset
+		return &shifted
+	case errors.As(err, &lexerErr):
+		shifted := *lexerErr
+		shifted.Offset += offset
+		return &shifted
+	}
+	return err
+}
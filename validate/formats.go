@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// formats are the standard formats of the format rule, named as in JSON Schema.
+var formats = map[string]func(string) bool{
+	"email":     isEmail,
+	"uri":       isURI,
+	"uuid":      uuidPattern.MatchString,
+	"date":      isLayout(time.DateOnly),
+	"date-time": isLayout(time.RFC3339Nano),
+	"time":      isTime,
+	"ipv4":      isIPv4,
+	"ipv6":      isIPv6,
+	"hostname":  isHostname,
+}
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+func isEmail(s string) bool {
+	address, err := mail.ParseAddress(s)
+	return err == nil && address.Address == s
+}
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+func isLayout(layout string) func(string) bool {
+	return func(s string) bool {
+		_, err := time.Parse(layout, s)
+		return err == nil
+	}
+}
+
+// isTime accepts the time part of a date-time, such as 10:30:00Z or 10:30:00.5+02:00.
+func isTime(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, "2000-01-01T"+s)
+	return err == nil
+}
+
+func isIPv4(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is4()
+}
+
+func isIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6() && addr.Zone() == ""
+}
+
+func isHostname(s string) bool {
+	return len(s) <= 253 && hostnamePattern.MatchString(s)
+}
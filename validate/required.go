@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/walk"
+	"github.com/mailru/easyjson"
+)
+
+// Required reports a Violation for every field with the required option that is not part of the payload v,
+// which must be a struct or a pointer to one. Optional and nullable containers are missing if they are
+// neither defined nor present, so a required optional field may be null but a required nullable field may not.
+// Pointers, slices, maps and interfaces are missing if they are nil.
+func Required(v any) error {
+	var violations Violations
+	err := walk.Fields(reflect.ValueOf(v), func(_ reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		if _, ok := f.Option("required"); ok {
+			violations = checkRequired(violations, f, value, path.Pointer)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return violations.err()
+}
+
+func checkRequired(violations Violations, f fields.Field, value reflect.Value, pointer string) Violations {
+	if fields.Defined(value) {
+		return violations
+	}
+	return append(violations, Violation{
+		Pointer: pointer,
+		Rule:    "required",
+		Message: fmt.Sprintf("%s is required", f.Name),
+	})
+}
+
+// Defaults fills the optional fields with the default option that are undefined in the payload v, which must be
+// a pointer to a struct. The default is decoded into the field as JSON, falling back to a JSON string
+// if it is not valid for the field, so `payload:"default=10"` fits an optional.Int and an optional.String.
+// Nullable and plain fields are left as they are, since a null or zero value cannot be told from a missing one.
+func Defaults(v any) error {
+	return walk.Fields(reflect.ValueOf(v), func(_ reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		def, ok := f.Option("default")
+		if !ok || !value.CanAddr() {
+			return true, nil
+		}
+		if kind, _ := fields.Container(f.Type); kind != fields.Optional || fields.StateOf(value) != fields.Undefined {
+			return true, nil
+		}
+		target := value.Addr().Interface()
+		if err := decodeDefault([]byte(def), target); err != nil {
+			quoted, _ := json.Marshal(def)
+			if err := decodeDefault(quoted, target); err != nil {
+				return false, fmt.Errorf("validate: invalid default %q for %s: %w", def, path.Pointer, err)
+			}
+		}
+		return true, nil
+	})
+}
+
+// decodeDefault decodes the default value into target, using easyjson if target implements its unmarshaler interface.
+func decodeDefault(data []byte, target any) error {
+	if !json.Valid(data) {
+		return errors.New("invalid JSON")
+	}
+	if u, ok := target.(easyjson.Unmarshaler); ok {
+		return easyjson.Unmarshal(data, u)
+	}
+	return json.Unmarshal(data, target)
+}
@@ -0,0 +1,56 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/binadel/payloads/nullable"
+	"github.com/binadel/payloads/optional"
+	"github.com/binadel/payloads/validate"
+)
+
+type settings struct {
+	Limit   optional.Int    `json:"limit" payload:"default=10"`
+	Offset  optional.Int    `json:"offset" payload:"default=5"`
+	Sort    optional.String `json:"sort" payload:"default=name"`
+	Filter  optional.String `json:"filter" payload:"default=all"`
+	Page    int             `json:"page" payload:"default=1"`
+	Comment nullable.String `json:"comment" payload:"default=none"`
+}
+
+func TestDefaults(t *testing.T) {
+	var s settings
+	if err := json.Unmarshal([]byte(`{"offset":0,"filter":null,"page":0}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if err := validate.Defaults(&s); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Limit.Lookup(); got != 10 {
+		t.Errorf("limit = %d, want the default 10", got)
+	}
+	if got, _ := s.Sort.Lookup(); got != "name" {
+		t.Errorf("sort = %q, want the default name", got)
+	}
+	if got, ok := s.Offset.Lookup(); !ok || got != 0 {
+		t.Errorf("offset = %d, want the decoded 0", got)
+	}
+	if !s.Filter.IsDefined() || s.Filter.IsPresent {
+		t.Errorf("filter = %+v, want the decoded null", s.Filter)
+	}
+	if s.Page != 0 {
+		t.Errorf("page = %d, want the decoded 0", s.Page)
+	}
+	if s.Comment.IsPresent {
+		t.Errorf("comment = %+v, want null", s.Comment)
+	}
+}
+
+func TestDefaultsInvalid(t *testing.T) {
+	var s struct {
+		Limit optional.Int `json:"limit" payload:"default=ten"`
+	}
+	if err := validate.Defaults(&s); err == nil {
+		t.Error("Defaults() = nil, want an error for an invalid default")
+	}
+}
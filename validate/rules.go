@@ -0,0 +1,278 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/binadel/payloads/internal/diff"
+	"github.com/binadel/payloads/internal/number"
+)
+
+// rule checks a present value against the argument of the rule, returning the message of the violation,
+// or an empty message if the value is valid. It returns an error if the rule does not fit the value.
+type rule func(v *Validator, value reflect.Value, arg string) (string, error)
+
+// rules are the rules that can be declared on a field besides required, as listed in Validator.Validate.
+var rules map[string]rule
+
+func init() {
+	rules = map[string]rule{
+		"min":         checkMin,
+		"max":         checkMax,
+		"minLength":   checkMinLength,
+		"maxLength":   checkMaxLength,
+		"pattern":     checkPattern,
+		"format":      checkFormat,
+		"minItems":    checkMinItems,
+		"maxItems":    checkMaxItems,
+		"uniqueItems": checkUniqueItems,
+	}
+}
+
+var errKind = errors.New("rule does not apply to the type of the field")
+
+func checkMin(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := numbers(value, arg)
+	if err != nil || n == nil || n.Cmp(bound) >= 0 {
+		return "", err
+	}
+	return fmt.Sprintf("must be at least %s", arg), nil
+}
+
+func checkMax(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := numbers(value, arg)
+	if err != nil || n == nil || n.Cmp(bound) <= 0 {
+		return "", err
+	}
+	return fmt.Sprintf("must be at most %s", arg), nil
+}
+
+// numbers returns the number held by value and the bound arg as exact rationals.
+// The number is nil for a float that is not finite, which is never compared.
+func numbers(value reflect.Value, arg string) (*big.Rat, *big.Rat, error) {
+	bound, err := number.Rat(arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	var n *big.Rat
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = new(big.Rat).SetInt64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = new(big.Rat).SetUint64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		if f := value.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			n = new(big.Rat).SetFloat64(f)
+		}
+	case reflect.String:
+		if value.Type() != reflect.TypeOf(json.Number("")) {
+			return nil, nil, errKind
+		}
+		if n, err = number.Rat(value.String()); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, errKind
+	}
+	return n, bound, nil
+}
+
+func checkMinLength(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := length(value, arg)
+	if err != nil || n >= bound {
+		return "", err
+	}
+	return fmt.Sprintf("must be at least %d characters long", bound), nil
+}
+
+func checkMaxLength(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := length(value, arg)
+	if err != nil || n <= bound {
+		return "", err
+	}
+	return fmt.Sprintf("must be at most %d characters long", bound), nil
+}
+
+// length returns the number of characters of the string held by value, and the bound arg.
+func length(value reflect.Value, arg string) (int, int, error) {
+	if value.Kind() != reflect.String {
+		return 0, 0, errKind
+	}
+	bound, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, 0, err
+	}
+	return utf8.RuneCountInString(value.String()), bound, nil
+}
+
+var patterns sync.Map
+
+func checkPattern(_ *Validator, value reflect.Value, arg string) (string, error) {
+	if value.Kind() != reflect.String {
+		return "", errKind
+	}
+	re, ok := patterns.Load(arg)
+	if !ok {
+		compiled, err := regexp.Compile(arg)
+		if err != nil {
+			return "", err
+		}
+		re, _ = patterns.LoadOrStore(arg, compiled)
+	}
+	if re.(*regexp.Regexp).MatchString(value.String()) {
+		return "", nil
+	}
+	return fmt.Sprintf("must match the pattern %s", arg), nil
+}
+
+func checkFormat(v *Validator, value reflect.Value, arg string) (string, error) {
+	if value.Kind() != reflect.String {
+		return "", errKind
+	}
+	check, ok := v.formats[arg]
+	if !ok {
+		return "", fmt.Errorf("unknown format %q", arg)
+	}
+	if check(value.String()) {
+		return "", nil
+	}
+	return fmt.Sprintf("must be a valid %s", arg), nil
+}
+
+func checkMinItems(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := items(value, arg)
+	if err != nil || n >= bound {
+		return "", err
+	}
+	return fmt.Sprintf("must have at least %d items", bound), nil
+}
+
+func checkMaxItems(_ *Validator, value reflect.Value, arg string) (string, error) {
+	n, bound, err := items(value, arg)
+	if err != nil || n <= bound {
+		return "", err
+	}
+	return fmt.Sprintf("must have at most %d items", bound), nil
+}
+
+// items returns the number of items of the array held by value, and the bound arg.
+func items(value reflect.Value, arg string) (int, int, error) {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return 0, 0, errKind
+	}
+	bound, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, 0, err
+	}
+	return value.Len(), bound, nil
+}
+
+func checkUniqueItems(_ *Validator, value reflect.Value, _ string) (string, error) {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", errKind
+	}
+	n := value.Len()
+	if hashable(value) {
+		seen := make(map[any]bool, n)
+		for i := 0; i < n; i++ {
+			item := itemKey(value.Index(i))
+			if seen[item] {
+				return "must not have duplicate items", nil
+			}
+			seen[item] = true
+		}
+		return "", nil
+	}
+	// Other items, such as maps, structs and pointers, are compared by their JSON form.
+	encoded := make([][]byte, n)
+	for i := range encoded {
+		data, err := diff.Exposed(value.Index(i))
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = data
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if diff.EqualJSON(encoded[i], encoded[j]) {
+				return "must not have duplicate items", nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// numberKey is the key of a number item, which is its exact value as a fraction, or NaN, +Inf or -Inf,
+// so that 1 and 1.0 are the same item, as in JSON, and NaN is equal to itself.
+type numberKey string
+
+// itemKey returns the key of an item of a hashable array: numbers are keyed by their value whatever their type.
+func itemKey(item reflect.Value) any {
+	for item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return nil
+		}
+		item = item.Elem()
+	}
+	switch item.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberKey(strconv.FormatInt(item.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return numberKey(strconv.FormatUint(item.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := item.Float()
+		switch {
+		case math.IsNaN(f):
+			return numberKey("NaN")
+		case math.IsInf(f, 0):
+			return numberKey(fmt.Sprint(f))
+		}
+		return numberKey(new(big.Rat).SetFloat64(f).RatString())
+	case reflect.Bool:
+		return item.Bool()
+	case reflect.String:
+		if item.Type() == jsonNumber {
+			if r, err := number.Rat(item.String()); err == nil {
+				return numberKey(r.RatString())
+			}
+		}
+		return item.String()
+	}
+	return item.Interface()
+}
+
+var jsonNumber = reflect.TypeOf(json.Number(""))
+
+// hashable determines whether the items can be compared as map keys with the same result as their JSON forms,
+// which is the case when the dynamic type of every item is a boolean, a number or a string.
+func hashable(value reflect.Value) bool {
+	if t := value.Type().Elem(); t.Kind() != reflect.Interface {
+		return isScalar(t.Kind())
+	}
+	for i := 0; i < value.Len(); i++ {
+		if item := value.Index(i); !item.IsNil() && !isScalar(item.Elem().Kind()) {
+			return false
+		}
+	}
+	return true
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
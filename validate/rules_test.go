@@ -0,0 +1,41 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/binadel/payloads/optional"
+	"github.com/binadel/payloads/validate"
+)
+
+func TestUniqueItems(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  any
+		unique bool
+	}{
+		{"Ints", []int{1, 2, 3}, true},
+		{"DuplicateInts", []int{1, 2, 1}, false},
+		{"IntAndFloat", []any{1, 1.0}, false},
+		{"IntAndUint", []any{int8(-1), uint(1), int64(1)}, false},
+		{"FloatAndFraction", []any{1, 1.5}, true},
+		{"NaN", []float64{math.NaN(), math.NaN()}, false},
+		{"Infinities", []float64{math.Inf(1), math.Inf(-1)}, true},
+		{"Numbers", []json.Number{"1", "1.0", "10e-1"}, false},
+		{"NumberAndString", []any{json.Number("1"), "1"}, true},
+		{"StringAndBool", []any{"true", true}, true},
+		{"Nulls", []any{nil, nil}, false},
+		{"Maps", []any{map[string]any{"a": 1}, map[string]any{"a": 1.0}}, false},
+		{"Secrets", []optional.Secret{optional.Of[optional.Secret]("a"), optional.Of[optional.Secret]("b")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.New().Rules(&struct{ Items any }{}, validate.Rules{"Items": "uniqueItems"}).
+				Validate(&struct{ Items any }{Items: tt.items})
+			if unique := err == nil; unique != tt.unique {
+				t.Errorf("Validate() = %v, want unique %v", err, tt.unique)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/binadel/payloads/internal/fields"
+	"github.com/binadel/payloads/internal/walk"
+)
+
+// Rules are rules declared in code for the fields of a struct, keyed by the JSON name of the field,
+// in the syntax of the payload tag, such as Rules{"name": "required,minLength=1"}.
+type Rules map[string]string
+
+// Validator checks payloads against the rules declared in the payload tags of their fields
+// and the rules added to it in code. It must not be modified once it is in use.
+type Validator struct {
+	rules   map[reflect.Type]map[string][]fields.Option
+	formats map[string]func(string) bool
+}
+
+// New returns a validator knowing the standard formats.
+func New() *Validator {
+	v := &Validator{
+		rules:   make(map[reflect.Type]map[string][]fields.Option),
+		formats: make(map[string]func(string) bool, len(formats)),
+	}
+	for name, check := range formats {
+		v.formats[name] = check
+	}
+	return v
+}
+
+// Rules adds rules for the fields of the struct type of sample, which is a struct or a pointer to one.
+// They apply in addition to the rules of the payload tags, so that types which cannot be tagged can be validated.
+// It returns the validator so that calls can be chained.
+func (v *Validator) Rules(sample any, rules Rules) *Validator {
+	t := reflect.TypeOf(sample)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: rules for non-struct type %v", t))
+	}
+	byField := v.rules[t]
+	if byField == nil {
+		byField = make(map[string][]fields.Option)
+		v.rules[t] = byField
+	}
+	for name, options := range rules {
+		if _, ok := fields.ByName(t, name); !ok {
+			panic(fmt.Sprintf("validate: rules for unknown field %s of %v", name, t))
+		}
+		byField[name] = append(byField[name], fields.ParseOptions(options)...)
+	}
+	return v
+}
+
+// Format adds a format for the format rule, or replaces the standard one with the same name.
+// It returns the validator so that calls can be chained.
+func (v *Validator) Format(name string, check func(string) bool) *Validator {
+	v.formats[name] = check
+	return v
+}
+
+// Validate checks the payload x, which is a struct or a pointer to one, against the rules of its fields:
+//
+//   - required, as checked by Required.
+//   - min=N and max=N bound a number, including a Number, inclusively.
+//   - minLength=N and maxLength=N bound the number of characters of a string.
+//   - pattern=RE requires a string to match the regular expression RE, which is not anchored.
+//   - format=NAME requires a string to be in the named format: email, uri, uuid, date, date-time,
+//     time, ipv4, ipv6, hostname, or one added with Format.
+//   - minItems=N and maxItems=N bound the number of items of an array.
+//   - uniqueItems requires the items of an array to have distinct JSON forms.
+//
+// The rules other than required are skipped for fields which are undefined, null or nil,
+// so that an optional field is only checked when it is present.
+//
+// It returns Violations listing every violation found, or another error if a rule is malformed.
+func (v *Validator) Validate(x any) error {
+	var violations Violations
+	err := walk.Fields(reflect.ValueOf(x), func(parent reflect.Type, f fields.Field, value reflect.Value, path walk.Path) (bool, error) {
+		pointer := path.Pointer
+		options := f.Options()
+		if extra := v.rules[parent][f.Name]; len(extra) > 0 {
+			options = append(options[:len(options):len(options)], extra...)
+		}
+		for _, o := range options {
+			if o.Name == "required" {
+				violations = checkRequired(violations, f, value, pointer)
+				continue
+			}
+			r, ok := rules[o.Name]
+			if !ok {
+				// The option belongs to another feature of the payload tag, such as default.
+				continue
+			}
+			held := walk.Held(value)
+			if !held.IsValid() {
+				continue
+			}
+			message, err := r(v, held, o.Arg)
+			if err != nil {
+				return false, fmt.Errorf("validate: invalid %s rule for %s: %w", o.Name, pointer, err)
+			}
+			if message != "" {
+				violations = append(violations, Violation{Pointer: pointer, Rule: o.Name, Message: message})
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return violations.err()
+}
+
+var std = New()
+
+// Validate checks the payload x with a validator having no rules declared in code, see Validator.Validate.
+func Validate(x any) error {
+	return std.Validate(x)
+}
@@ -0,0 +1,54 @@
+// Package validate checks decoded payloads against the rules declared in the payload tag of their fields,
+// such as `payload:"required,minLength=1"`, or in code, reporting each violation with the JSON Pointer of the value.
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/binadel/payloads/problem"
+)
+
+// Violation is a rule that a value of the payload does not satisfy.
+type Violation struct {
+	// Pointer is the JSON Pointer of the value, such as "/items/3/price".
+	Pointer string
+
+	// Rule is the name of the rule, such as "required".
+	Rule string
+
+	// Message is a human-readable explanation of the violation.
+	Message string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Pointer, v.Message)
+}
+
+// Violations is the error reporting all the violations found in a payload.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Problem returns the details of a validation problem listing the violations.
+func (v Violations) Problem() problem.Details {
+	errs := make([]problem.Error, len(v))
+	for i, violation := range v {
+		errs[i] = problem.Error{Detail: violation.Message, Pointer: violation.Pointer}
+	}
+	return problem.Validation(errs...)
+}
+
+// err returns the violations as an error, or nil if there is none.
+func (v Violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}